@@ -0,0 +1,178 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// ATCType enum type, the payload type of a SMPTE ST 12-2 ancillary timecode packet, in its
+// distributed binary bits 1 (DBB1).
+type ATCType uint8
+
+const (
+	// ATCLTC is linear timecode, ATC_LTC.
+	ATCLTC ATCType = 0x00
+	// ATCVITC1 is vertical interval timecode of the first field, ATC_VITC1.
+	ATCVITC1 ATCType = 0x01
+	// ATCVITC2 is vertical interval timecode of the second field, ATC_VITC2.
+	ATCVITC2 ATCType = 0x02
+)
+
+const (
+	// _atcDID and _atcSDID are the data identifier and secondary data identifier of ST 12-2
+	// timecode in a ST 291 ancillary data packet.
+	_atcDID  = 0x60
+	_atcSDID = 0x60
+	// _atcWords is the number of user data words of a packet, 4 bits of the timecode and
+	// a bit of DBB1 or DBB2 in each.
+	_atcWords = 16
+)
+
+// _ancDataFlag is the ancillary data flag before a ST 291 packet in a 10 bit stream.
+var _ancDataFlag = []uint16{0x000, 0x3FF, 0x3FF}
+
+// ATCPacket is a SMPTE ST 12-2 ancillary timecode packet, the digits, flags and user bits
+// of a ST 12-1 timecode with the distributed binary bits, carried in a ST 291 ancillary
+// data packet in SDI. Frame rates above 30 fps are carried as ST 12-3 frame pairs, with the
+// index of the frame in the pair in place of the field mark.
+type ATCPacket struct {
+	// TimeCode is the timecode of the packet.
+	TimeCode TimeCode
+	// UserBits are the user bits of the packet.
+	UserBits UserBits
+	// Flags are the color frame and binary group flags of the packet.
+	Flags LTCFlags
+	// Type is the payload type in DBB1.
+	Type ATCType
+	// DBB2 are the distributed binary bits 2, the VITC line select, line duplication,
+	// validity and process flags.
+	DBB2 uint8
+}
+
+// ancWord Returns the 10 bit ancillary data word of a byte, bit 8 the even parity of
+// bits 0 to 7 and bit 9 the inverse of bit 8.
+func ancWord(b uint8) uint16 {
+	parity := uint16(bits.OnesCount8(b) % 2)
+	return uint16(b) | parity<<8 | (parity^1)<<9
+}
+
+// ancChecksum Returns the checksum word of the words from the DID to the last user data
+// word, the sum of their bits 0 to 8, with bit 9 the inverse of bit 8.
+func ancChecksum(words []uint16) uint16 {
+	sum := uint16(0)
+	for _, word := range words {
+		sum += word & 0x1FF
+	}
+	sum &= 0x1FF
+	return sum | (^sum>>8&1)<<9
+}
+
+// Words Returns the ST 291 ancillary data packet of this instance as 10 bit words, the
+// DID, SDID, data count, 16 user data words and checksum, without the ancillary data flag.
+func (p ATCPacket) Words() ([]uint16, error) {
+	tc, index := p.TimeCode, -1
+	if _, frames, err := PairBase(tc.FrameRate()); err == nil && frames == 2 {
+		pair, err := tc.FramePair()
+		if err != nil {
+			return nil, err
+		}
+		tc, index = pair.Label, pair.Index
+	}
+	layout, err := ltcRateLayout(tc.FrameRate())
+	if err != nil {
+		return nil, err
+	}
+	frame, err := EncodeLTCFlags(tc, p.UserBits, p.Flags)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case index >= 0:
+		frame.setBits(layout.polarity, 1, index)
+	case p.Type == ATCVITC1 || p.Type == ATCVITC2:
+		frame.setBits(layout.polarity, 1, tc.Field())
+	}
+
+	ret := []uint16{ancWord(_atcDID), ancWord(_atcSDID), ancWord(_atcWords)}
+	for i := uint(0); i < _atcWords; i++ {
+		dbb := uint8(p.Type)
+		if i >= 8 {
+			dbb = p.DBB2
+		}
+		ret = append(ret, ancWord(uint8(frame.bits(4*i, 4))<<4|(dbb>>(i%8)&1)<<3))
+	}
+	return append(ret, ancChecksum(ret)), nil
+}
+
+// ParseATC Parses a ST 12-2 ancillary timecode packet from 10 bit words at the frame rate,
+// with or without the ancillary data flag before it, after checking the parity of the
+// words and the checksum. Like DecodeLTC the drop frame flag selects the drop frame or
+// non drop frame variant of the rate.
+func ParseATC(words []uint16, rate SmpteFrameRate) (*ATCPacket, error) {
+	if len(words) == len(_ancDataFlag)+_atcWords+4 {
+		for i, word := range _ancDataFlag {
+			if words[i] != word {
+				return nil, errors.New("timecode: invalid ancillary data flag")
+			}
+		}
+		words = words[len(_ancDataFlag):]
+	}
+	if len(words) != _atcWords+4 {
+		return nil, fmt.Errorf("timecode: invalid number of ATC words: '%v'", len(words))
+	}
+	for _, word := range words[:len(words)-1] {
+		if word > 0x3FF || ancWord(uint8(word)) != word {
+			return nil, fmt.Errorf("timecode: ATC word fails the parity: '%#x'", word)
+		}
+	}
+	if words[0] != ancWord(_atcDID) || words[1] != ancWord(_atcSDID) || words[2] != ancWord(_atcWords) {
+		return nil, errors.New("timecode: ancillary data packet is not ATC")
+	}
+	if ancChecksum(words[:len(words)-1]) != words[len(words)-1] {
+		return nil, errors.New("timecode: ATC packet fails the checksum")
+	}
+
+	base, frames, err := PairBase(rate)
+	if err != nil {
+		return nil, err
+	}
+	if frames > 2 {
+		return nil, fmt.Errorf("timecode: frame rate is not carried by ATC: '%v'", rate)
+	}
+	layout, err := ltcRateLayout(base)
+	if err != nil {
+		return nil, err
+	}
+	var frame LTCFrame
+	ret := &ATCPacket{}
+	for i, word := range words[3 : 3+_atcWords] {
+		frame.setBits(4*uint(i), 4, int(word>>4&0xF))
+		if i < 8 {
+			ret.Type |= ATCType(word>>3&1) << uint(i)
+		} else {
+			ret.DBB2 |= uint8(word>>3&1) << uint(i-8)
+		}
+	}
+	frame.setBits(64, 16, _ltcSyncWord)
+	tc, userBits, err := DecodeLTC(frame, base)
+	if err != nil {
+		return nil, err
+	}
+	mark := frame.bits(layout.polarity, 1)
+	switch {
+	case frames == 2:
+		if tc, err = FromFramePair(FramePair{Label: *tc, Index: mark}, RateFromRational(rate.Rational(), tc.FrameRate().IsDropFrame())); err != nil {
+			return nil, err
+		}
+	case mark == 1 && (ret.Type == ATCVITC1 || ret.Type == ATCVITC2):
+		field, err := tc.PlusFields(1)
+		if err != nil {
+			return nil, err
+		}
+		tc = &field
+	}
+	ret.TimeCode, ret.UserBits = *tc, userBits
+	ret.Flags, _ = frame.Flags(base)
+	return ret, nil
+}
@@ -0,0 +1,115 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ATCPacket_Words(t *testing.T) {
+	tc, _ := FromTimeCode("12:34:56:07", Smpte30)
+	words, err := ATCPacket{TimeCode: *tc, UserBits: 0x87654321, Type: ATCLTC, DBB2: 0x81}.Words()
+	assert.Nil(t, err)
+	assert.Equal(t, 20, len(words))
+	// DID, SDID and data count, with parity
+	assert.Equal(t, []uint16{0x260, 0x260, 0x110}, words[:3])
+	// frame units 7, user bits group 1, frame tens 0 and user bits group 2
+	assert.Equal(t, []uint16{0x170, 0x110, 0x200, 0x120}, words[3:7])
+	// DBB2 in bit 3 of the last 8 user data words
+	assert.Equal(t, uint16(0x8), words[11]&0x8)
+	assert.Equal(t, uint16(0x8), words[18]&0x8)
+	for _, word := range words[3:19] {
+		assert.Equal(t, uint16(0), word&0x7)
+		assert.Equal(t, word, ancWord(uint8(word)))
+	}
+	// the checksum, 9 bits of the sum with bit 9 the inverse of bit 8
+	sum := uint16(0)
+	for _, word := range words[:19] {
+		sum += word & 0x1FF
+	}
+	assert.Equal(t, sum&0x1FF, words[19]&0x1FF)
+	assert.Equal(t, (^words[19]>>8)&1, words[19]>>9)
+
+	tc, _ = FromTimeCode("00:00:00:00", Smpte120)
+	_, err = ATCPacket{TimeCode: *tc}.Words()
+	assert.NotNil(t, err)
+	tc, _ = FromTimeCode("-00:00:01:00", Smpte25)
+	_, err = ATCPacket{TimeCode: *tc}.Words()
+	assert.NotNil(t, err)
+}
+
+func Test_ParseATC(t *testing.T) {
+	tests := []struct {
+		tc   string
+		rate SmpteFrameRate
+		typ  ATCType
+	}{
+		{"01:02:03:04.0", Smpte25, ATCLTC},
+		{"01:02:03:04.1", Smpte25, ATCVITC2},
+		{"23:59:59;29.0", Smpte2997Drop, ATCVITC1},
+		{"23:59:59;29.1", Smpte2997Drop, ATCVITC2},
+		{"10:00:00:23.0", Smpte24, ATCLTC},
+		{"10:00:00:29.1", Smpte30, ATCVITC1},
+	}
+	for _, test := range tests {
+		tc, _ := FromFieldTimeCode(test.tc, test.rate)
+		packet := ATCPacket{TimeCode: *tc, UserBits: 0x0BADCAFE, Type: test.typ, DBB2: 0x45,
+			Flags: LTCFlags{ColorFrame: true, BinaryGroup: [3]bool{false, true, false}}}
+		words, err := packet.Words()
+		assert.Nil(t, err, test.tc)
+		back, err := ParseATC(words, test.rate)
+		assert.Nil(t, err, test.tc)
+		assert.Equal(t, test.tc, back.TimeCode.StringField(), test.tc)
+		assert.Equal(t, packet.UserBits, back.UserBits, test.tc)
+		assert.Equal(t, packet.Flags, back.Flags, test.tc)
+		assert.Equal(t, test.typ, back.Type, test.tc)
+		assert.Equal(t, uint8(0x45), back.DBB2, test.tc)
+		// with the ancillary data flag
+		back, err = ParseATC(append([]uint16{0x000, 0x3FF, 0x3FF}, words...), test.rate)
+		assert.Nil(t, err, test.tc)
+		assert.Equal(t, test.tc, back.TimeCode.StringField(), test.tc)
+	}
+
+	// high frame rates as frame pairs
+	for _, s := range []string{"01:00:00;44", "01:00:00;45"} {
+		tc, _ := FromTimeCode(s, Smpte5994Drop)
+		words, err := ATCPacket{TimeCode: *tc}.Words()
+		assert.Nil(t, err)
+		back, err := ParseATC(words, Smpte5994NonDrop)
+		assert.Nil(t, err)
+		assert.Equal(t, s, back.TimeCode.String())
+		assert.Equal(t, Smpte5994Drop, back.TimeCode.FrameRate())
+	}
+	tc, _ := FromTimeCode("10:00:00:49", Smpte50)
+	words, _ := ATCPacket{TimeCode: *tc}.Words()
+	// the label is 10:00:00:24 at 25 fps
+	assert.Equal(t, uint16(0x4), words[3]>>4&0xF)
+	back, _ := ParseATC(words, Smpte50)
+	assert.Equal(t, "10:00:00:49", back.TimeCode.String())
+
+	// errors
+	tc, _ = FromTimeCode("01:00:00:00", Smpte25)
+	words, _ = ATCPacket{TimeCode: *tc}.Words()
+	_, err := ParseATC(words[:19], Smpte25)
+	assert.NotNil(t, err)
+	_, err = ParseATC(append([]uint16{0x000, 0x3FF, 0x3FE}, words...), Smpte25)
+	assert.NotNil(t, err)
+	_, err = ParseATC(words, Smpte120)
+	assert.NotNil(t, err)
+	bad := append([]uint16(nil), words...)
+	bad[5] ^= 0x10
+	_, err = ParseATC(bad, Smpte25)
+	assert.NotNil(t, err)
+	bad = append([]uint16(nil), words...)
+	bad[5] ^= 0x310
+	_, err = ParseATC(bad, Smpte25)
+	assert.NotNil(t, err)
+	bad = append([]uint16(nil), words...)
+	bad[19] ^= 1
+	_, err = ParseATC(bad, Smpte25)
+	assert.NotNil(t, err)
+	bad = append([]uint16(nil), words...)
+	bad[1] = ancWord(0x61)
+	_, err = ParseATC(bad, Smpte25)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,71 @@
+package timecode
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// validateSampleRate Checks that the sample rate and frame rate can be used together.
+func validateSampleRate(sampleRate int64, rate SmpteFrameRate) error {
+	if sampleRate <= 0 {
+		return fmt.Errorf("timecode: invalid sample rate: '%v'", sampleRate)
+	}
+	if _, ok := lookupRate(rate); !ok {
+		return fmt.Errorf(_unknownFrameRate, rate)
+	}
+	return nil
+}
+
+// absoluteTimeToSamples Returns the nearest audio sample to the absolute time, halves are
+// rounded away from zero.
+func absoluteTimeToSamples(absoluteTime *big.Rat, sampleRate int64) int64 {
+	return roundRat(new(big.Rat).Mul(absoluteTime, newRat(sampleRate, 1)), 0).Num().Int64()
+}
+
+// FromSamples Initializes a new instance of the TimeCode struct from a number of audio
+// samples at the sample rate, e.g. 48000 samples at 48 kHz is one second. The time is
+// kept exactly, so it is usually between two frames, see SubFrame.
+func FromSamples(samples, sampleRate int64, rate SmpteFrameRate) (*TimeCode, error) {
+	if err := validateSampleRate(sampleRate, rate); err != nil {
+		return nil, err
+	}
+	return fromAbsoluteTime(newRat(samples, sampleRate), rate)
+}
+
+// ToSamples Returns the value of this instance in audio samples at the sample rate, the
+// nearest sample to the absolute time. The start of a frame is the first sample of the
+// frame in FrameSamples, which can be up to half a sample before the exact start of the
+// frame, so FromSamples of it may be in the frame before.
+func (m TimeCode) ToSamples(sampleRate int64) int64 {
+	return absoluteTimeToSamples(m.absolute(), sampleRate)
+}
+
+// FrameSamples Returns the exact number of audio samples at the sample rate in frame N
+// of the frame rate. Each frame starts at the nearest sample to its absolute time, so at
+// 29.97 fps and 48 kHz, where a frame is 1601.6 samples, frames 0 to 4 have the cadence
+// 1602, 1601, 1602, 1601, 1602, repeating every 5 frames.
+func FrameSamples(frame, sampleRate int64, rate SmpteFrameRate) (int64, error) {
+	if err := validateSampleRate(sampleRate, rate); err != nil {
+		return 0, err
+	}
+	start := absoluteTimeToSamples(framesToAbsoluteTime(frame, rate), sampleRate)
+	end := absoluteTimeToSamples(framesToAbsoluteTime(frame+1, rate), sampleRate)
+	return end - start, nil
+}
+
+// SampleCadence Returns the number of audio samples at the sample rate in each frame of
+// the shortest repeating sequence of frames of the frame rate, starting at frame 0, e.g.
+// [1602 1601 1602 1601 1602] for 29.97 fps at 48 kHz, and [1920] for 25 fps.
+func SampleCadence(sampleRate int64, rate SmpteFrameRate) ([]int64, error) {
+	if err := validateSampleRate(sampleRate, rate); err != nil {
+		return nil, err
+	}
+	// the cadence repeats once a whole number of samples is a whole number of frames
+	rec := rateRecord(rate)
+	samplesPerFrame := newRat(sampleRate*rec.den, rec.num)
+	cadence := make([]int64, samplesPerFrame.Denom().Int64())
+	for frame := range cadence {
+		cadence[frame], _ = FrameSamples(int64(frame), sampleRate, rate)
+	}
+	return cadence, nil
+}
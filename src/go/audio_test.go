@@ -0,0 +1,90 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromSamples(t *testing.T) {
+	tc, err := FromSamples(48000, 48000, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00", tc.String())
+	assert.Equal(t, int64(48000), tc.ToSamples(48000))
+	assert.Equal(t, int64(96000), tc.ToSamples(96000))
+	// a 29.97 frame is 1601.6 samples
+	tc, _ = FromSamples(1601, 48000, Smpte2997NonDrop)
+	assert.Equal(t, int64(0), tc.TotalFrames())
+	tc, _ = FromSamples(1602, 48000, Smpte2997NonDrop)
+	assert.Equal(t, int64(1), tc.TotalFrames())
+	assert.Equal(t, "1/4004", tc.SubFrame().RatString())
+	// an hour of 29.97 drop frame
+	tc, _ = FromTimeCode("01:00:00;00", Smpte2997Drop)
+	assert.Equal(t, int64(172799827), tc.ToSamples(48000))
+	// the nearest sample is a fifth of a sample before the frame
+	back, _ := FromSamples(tc.ToSamples(48000), 48000, Smpte2997Drop)
+	assert.Equal(t, "00:59:59;29", back.String())
+	back, _ = FromSamples(tc.ToSamples(48000)+1, 48000, Smpte2997Drop)
+	assert.Equal(t, "01:00:00;00", back.String())
+	tc, _ = FromTimeCode("01:00:00:00", Smpte25)
+	back, _ = FromSamples(tc.ToSamples(96000), 96000, Smpte25)
+	assert.Equal(t, 0, tc.Cmp(*back))
+	// negative durations
+	tc, _ = FromSamples(-48000, 48000, Smpte24)
+	assert.Equal(t, "-00:00:01:00", tc.String())
+	assert.Equal(t, int64(-48000), tc.ToSamples(48000))
+	// errors
+	_, err = FromSamples(1, 0, Smpte25)
+	assert.NotNil(t, err)
+	_, err = FromSamples(1, 48000, Unknown)
+	assert.NotNil(t, err)
+}
+
+func Test_FrameSamples(t *testing.T) {
+	cadence := []int64{1602, 1601, 1602, 1601, 1602}
+	start := int64(0)
+	for frame := int64(0); frame < 50; frame++ {
+		samples, err := FrameSamples(frame, 48000, Smpte2997NonDrop)
+		assert.Nil(t, err)
+		assert.Equal(t, cadence[frame%5], samples, "frame %v", frame)
+		// each frame starts at the sum of the samples of the frames before it
+		tc, _ := FromFrames(frame, Smpte2997NonDrop)
+		assert.Equal(t, start, tc.ToSamples(48000), "frame %v", frame)
+		start += samples
+	}
+	samples, _ := FrameSamples(12345, 48000, Smpte25)
+	assert.Equal(t, int64(1920), samples)
+	_, err := FrameSamples(0, -1, Smpte25)
+	assert.NotNil(t, err)
+}
+
+func Test_SampleCadence(t *testing.T) {
+	tests := []struct {
+		sampleRate int64
+		rate       SmpteFrameRate
+		expected   []int64
+	}{
+		{48000, Smpte25, []int64{1920}},
+		{48000, Smpte24, []int64{2000}},
+		{48000, Smpte2997Drop, []int64{1602, 1601, 1602, 1601, 1602}},
+		{48000, Smpte2997NonDrop, []int64{1602, 1601, 1602, 1601, 1602}},
+		{96000, Smpte2997NonDrop, []int64{3203, 3203, 3204, 3203, 3203}},
+		{48000, Smpte5994NonDrop, []int64{801, 801, 800, 801, 801}},
+		{48000, Smpte2398, []int64{2002}},
+	}
+	for _, test := range tests {
+		cadence, err := SampleCadence(test.sampleRate, test.rate)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, cadence, "%v at %v", test.rate, test.sampleRate)
+	}
+	// 1471.47 samples a frame repeat every 100 frames
+	cadence, _ := SampleCadence(44100, Smpte2997NonDrop)
+	assert.Equal(t, 100, len(cadence))
+	sum := int64(0)
+	for _, samples := range cadence {
+		sum += samples
+	}
+	assert.Equal(t, int64(147147), sum)
+	_, err := SampleCadence(48000, Unknown)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,146 @@
+package timecode
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// FieldDominance enum type, the field of an interlaced frame that comes first in time.
+type FieldDominance int
+
+const (
+	// UpperFieldFirst the upper (top) field is first, e.g. HD 1080i.
+	UpperFieldFirst FieldDominance = 0
+
+	// LowerFieldFirst the lower (bottom) field is first, e.g. SD DV.
+	LowerFieldFirst FieldDominance = 1
+)
+
+// interlacedRate Returns the rate record of a frame rate that can be interlaced, that is
+// at most 30 fps, e.g. Smpte25 for 50i and Smpte2997Drop for 59.94i.
+func interlacedRate(rate SmpteFrameRate) (*rateRec, error) {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return nil, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	if rec.frames > _maxPairRate {
+		return nil, fmt.Errorf("timecode: frame rate is not interlaced: '%v'", rate)
+	}
+	return rec, nil
+}
+
+// fieldsToAbsoluteTime Returns the absolute time of a number of fields, two fields a frame.
+func fieldsToAbsoluteTime(fields int64, rec *rateRec) *big.Rat {
+	return newRat(fields*rec.den, 2*rec.num)
+}
+
+// FromFields Initializes a new instance of the TimeCode struct from a number of fields of
+// an interlaced frame rate, e.g. 50 fields of Smpte25 is one second.
+func FromFields(fields int64, rate SmpteFrameRate) (*TimeCode, error) {
+	rec, err := interlacedRate(rate)
+	if err != nil {
+		return nil, err
+	}
+	return fromAbsoluteTime(fieldsToAbsoluteTime(fields, rec), rate)
+}
+
+// FromFieldTimeCode Initializes a new instance of the TimeCode struct using a time code
+// string with a field, such as "10:00:00:12.1", where .0 is the first field of the frame
+// and .1 the second. Without a field the time code is at the first field.
+func FromFieldTimeCode(timeCode string, rate SmpteFrameRate) (*TimeCode, error) {
+	field := int64(0)
+	if index := strings.LastIndex(timeCode, "."); index >= 0 {
+		f, err := strconv.ParseInt(timeCode[index+1:], 10, 64)
+		if err != nil || f < 0 || f > 1 {
+			return nil, fmt.Errorf("timecode: invalid field: '%s'", timeCode)
+		}
+		timeCode, field = timeCode[:index], f
+	}
+	tc, err := FromTimeCode(timeCode, rate)
+	if err != nil {
+		return nil, err
+	}
+	// the field is further from zero for a negative duration
+	fields := 2 * tc.TotalFrames()
+	if strings.HasPrefix(timeCode, "-") {
+		fields -= field
+	} else {
+		fields += field
+	}
+	return FromFields(fields, rate)
+}
+
+// TotalFields Gets the value of the current TimeCode structure expressed in fields.
+// Negative times are truncated towards zero, like TotalFrames.
+func (m TimeCode) TotalFields() int64 {
+	rec := rateRecord(m.frameRate)
+	fields := new(big.Rat).Mul(m.absolute(), newRat(2*rec.num, rec.den))
+	if fields.Sign() < 0 {
+		return -floorRat(fields.Neg(fields))
+	}
+	return floorRat(fields)
+}
+
+// Field Gets the field of the frame of this instance, 0 for the first field in time and
+// 1 for the second.
+func (m TimeCode) Field() int {
+	fields := m.TotalFields()
+	if fields < 0 {
+		fields = -fields
+	}
+	return int(fields % 2)
+}
+
+// UpperField Indicates whether the field of this instance is the upper field of the
+// frame, according to the field dominance.
+func (m TimeCode) UpperField() bool {
+	return (m.Field() == 0) == (m.dominance == UpperFieldFirst)
+}
+
+// FieldDominance Gets the field dominance of this instance.
+func (m TimeCode) FieldDominance() FieldDominance {
+	return m.dominance
+}
+
+// WithFieldDominance returns a copy of this instance using the field dominance.
+func (m TimeCode) WithFieldDominance(dominance FieldDominance) TimeCode {
+	m.dominance = dominance
+	return m
+}
+
+// SetFieldDominance sets the field dominance of this instance.
+func (m *TimeCode) SetFieldDominance(dominance FieldDominance) {
+	m.dominance = dominance
+}
+
+// StringField Returns the SMPTE 12M string representation of this instance with the
+// field, e.g. "10:00:00:12.1".
+func (m TimeCode) StringField() string {
+	return fmt.Sprintf("%s.%d", m.signedString(), m.Field())
+}
+
+// PlusFields returns a new TimeCode that is this instance plus a number of fields.
+func (m TimeCode) PlusFields(fields int64) (TimeCode, error) {
+	rec, err := interlacedRate(m.frameRate)
+	if err != nil {
+		return m, err
+	}
+	return m.withAbsoluteTime(new(big.Rat).Add(m.absolute(), fieldsToAbsoluteTime(fields, rec)))
+}
+
+// MinusFields returns a new TimeCode that is this instance minus a number of fields.
+func (m TimeCode) MinusFields(fields int64) (TimeCode, error) {
+	return m.PlusFields(-fields)
+}
+
+// AddFields ..
+func (m *TimeCode) AddFields(fields int64) error {
+	return m.assign(m.PlusFields(fields))
+}
+
+// SubFields ..
+func (m *TimeCode) SubFields(fields int64) error {
+	return m.assign(m.MinusFields(fields))
+}
@@ -0,0 +1,109 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromFields(t *testing.T) {
+	tc, err := FromFields(50, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00", tc.String())
+	assert.Equal(t, "00:00:01:00.0", tc.StringField())
+	tc, err = FromFields(25, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:12.1", tc.StringField())
+	assert.Equal(t, int64(12), tc.TotalFrames())
+	assert.Equal(t, int64(25), tc.TotalFields())
+	assert.Equal(t, "1/2", tc.AbsoluteTime().RatString())
+	// 59.94i drop frame
+	tc, err = FromFields(2*1800+1, Smpte2997Drop)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02.1", tc.StringField())
+	// progressive high frame rates have no fields
+	_, err = FromFields(1, Smpte50)
+	assert.NotNil(t, err)
+	_, err = FromFields(1, Unknown)
+	assert.NotNil(t, err)
+}
+
+func Test_FromFieldTimeCode(t *testing.T) {
+	tc, err := FromFieldTimeCode("10:00:00:12.1", Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2*900012+1), tc.TotalFields())
+	assert.Equal(t, 1, tc.Field())
+	assert.Equal(t, "10:00:00:12.1", tc.StringField())
+	tc, err = FromFieldTimeCode("10:00:00;12.0", Smpte2997Drop)
+	assert.Nil(t, err)
+	assert.Equal(t, "10:00:00;12.0", tc.StringField())
+	tc, err = FromFieldTimeCode("00:00:01:00", Smpte2997NonDrop)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00.0", tc.StringField())
+	// negative durations
+	tc, err = FromFieldTimeCode("-00:00:00:00.1", Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), tc.TotalFields())
+	assert.Equal(t, "-00:00:00:00.1", tc.StringField())
+	tc, _ = FromFieldTimeCode("-00:00:01:03.1", Smpte25)
+	assert.Equal(t, "-00:00:01:03.1", tc.StringField())
+	// bad fields
+	for _, timeCode := range []string{"00:00:01:00.2", "00:00:01:00.", "00:00:01:00.x", "00:00:01:00.-1"} {
+		_, err = FromFieldTimeCode(timeCode, Smpte25)
+		assert.NotNil(t, err, timeCode)
+	}
+	_, err = FromFieldTimeCode("00:00:01:00.1", Smpte60)
+	assert.NotNil(t, err)
+}
+
+func Test_FieldArithmetic(t *testing.T) {
+	tc, _ := FromFieldTimeCode("00:00:00:24.1", Smpte25)
+	next, err := tc.PlusFields(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00.0", next.StringField())
+	assert.Equal(t, "00:00:00:24.1", tc.StringField())
+	prev, err := next.MinusFields(3)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:23.1", prev.StringField())
+	// across a drop frame minute
+	tc, _ = FromFieldTimeCode("00:00:59;29.1", Smpte2997Drop)
+	err = tc.AddFields(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02.0", tc.StringField())
+	err = tc.SubFields(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:59;29.0", tc.StringField())
+	// frame arithmetic keeps the field
+	tc, _ = FromFieldTimeCode("00:00:10:05.1", Smpte2997NonDrop)
+	v, _ := tc.PlusFrames(10)
+	assert.Equal(t, "00:00:10:15.1", v.StringField())
+	// overflow policy applies
+	tc, _ = FromFieldTimeCode("00:00:00:00.0", Smpte25)
+	_, err = tc.MinusFields(1)
+	assert.NotNil(t, err)
+	v, err = tc.WithOverflowPolicy(OverflowSigned).MinusFields(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00:00.1", v.StringField())
+	// field arithmetic needs an interlaced frame rate
+	tc, _ = FromFrames(10, Smpte5994NonDrop)
+	_, err = tc.PlusFields(1)
+	assert.NotNil(t, err)
+}
+
+func Test_FieldDominance(t *testing.T) {
+	tc, _ := FromFieldTimeCode("10:00:00:12.0", Smpte25)
+	assert.Equal(t, UpperFieldFirst, tc.FieldDominance())
+	assert.True(t, tc.UpperField())
+	second, _ := tc.PlusFields(1)
+	assert.False(t, second.UpperField())
+	assert.Equal(t, UpperFieldFirst, second.FieldDominance())
+
+	lower := tc.WithFieldDominance(LowerFieldFirst)
+	assert.Equal(t, UpperFieldFirst, tc.FieldDominance())
+	assert.False(t, lower.UpperField())
+	second, _ = lower.PlusFields(1)
+	assert.True(t, second.UpperField())
+	assert.Equal(t, LowerFieldFirst, second.FieldDominance())
+	tc.SetFieldDominance(LowerFieldFirst)
+	assert.Equal(t, LowerFieldFirst, tc.FieldDominance())
+}
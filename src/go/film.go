@@ -0,0 +1,140 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// FilmGauge enum type, the film gauge and pulldown of feet and frames.
+type FilmGauge int
+
+const (
+	// Film35mm4Perf 35mm film with 4 perforations a frame, 16 frames a foot.
+	Film35mm4Perf FilmGauge = 0
+
+	// Film35mm3Perf 35mm film with 3 perforations a frame, 21 1/3 frames a foot.
+	Film35mm3Perf FilmGauge = 1
+
+	// Film35mm2Perf 35mm film with 2 perforations a frame, 32 frames a foot.
+	Film35mm2Perf FilmGauge = 2
+
+	// Film16mm 16mm film with 1 perforation a frame, 40 frames a foot.
+	Film16mm FilmGauge = 3
+)
+
+type gaugeRec struct {
+	perfsPerFrame int64
+	perfsPerFoot  int64
+	// perfsPerKey is the interval of the key numbers of KeyKode edge codes
+	perfsPerKey int64
+}
+
+var _gaugeRecords = map[FilmGauge]*gaugeRec{
+	Film35mm4Perf: &gaugeRec{perfsPerFrame: 4, perfsPerFoot: 64, perfsPerKey: 64},
+	Film35mm3Perf: &gaugeRec{perfsPerFrame: 3, perfsPerFoot: 64, perfsPerKey: 64},
+	Film35mm2Perf: &gaugeRec{perfsPerFrame: 2, perfsPerFoot: 64, perfsPerKey: 64},
+	Film16mm:      &gaugeRec{perfsPerFrame: 1, perfsPerFoot: 40, perfsPerKey: 20},
+}
+
+// _validateFeetFrames Regular expression object used for parsing feet and frames, with
+// an optional perforation offset, e.g. "1234+05" or "1+00.2".
+var _validateFeetFrames = regexp.MustCompile(`^(\d+)\+(\d+)(?:\.(\d+))?$`)
+
+// FeetFrames is a film footage, the feet and frames of a frame counted from the start of
+// the film. With 3-perf a frame does not always start on a foot, so a frame can start a
+// number of perforations after the start of its frame number in the foot.
+type FeetFrames struct {
+	Feet   int64
+	Frames int64
+	// Perfs is the perforation offset of the frame from the start of frame number Frames.
+	Perfs int64
+}
+
+// String returns the footage in the format "1234+05", with the perforation offset when
+// it is not zero, e.g. "1+00.2".
+func (f FeetFrames) String() string {
+	if f.Perfs != 0 {
+		return fmt.Sprintf("%d+%02d.%d", f.Feet, f.Frames, f.Perfs)
+	}
+	return fmt.Sprintf("%d+%02d", f.Feet, f.Frames)
+}
+
+// gaugeRecord Returns the record of the film gauge.
+func gaugeRecord(gauge FilmGauge) (*gaugeRec, error) {
+	rec, ok := _gaugeRecords[gauge]
+	if !ok {
+		return nil, fmt.Errorf("timecode: unknown film gauge: '%v'", int(gauge))
+	}
+	return rec, nil
+}
+
+// framesToFeetFrames Converts a non-negative frame count to feet and frames, of a foot
+// of perfsPerFoot perforations and a frame of perfsPerFrame perforations.
+func framesToFeetFrames(framecount, perfsPerFoot, perfsPerFrame int64) FeetFrames {
+	perfs := framecount * perfsPerFrame
+	inFoot := perfs % perfsPerFoot
+	return FeetFrames{
+		Feet:   perfs / perfsPerFoot,
+		Frames: inFoot / perfsPerFrame,
+		Perfs:  inFoot % perfsPerFrame,
+	}
+}
+
+// feetFramesToFrames Converts feet and frames, of a foot of perfsPerFoot perforations and
+// a frame of perfsPerFrame perforations, to a frame count. Without a perforation offset it
+// is the first frame starting at or after the frame number, and with one a frame must
+// start at the perforation.
+func feetFramesToFrames(footage FeetFrames, explicitPerfs bool, perfsPerFoot, perfsPerFrame int64) (int64, error) {
+	if footage.Feet < 0 || footage.Frames < 0 || footage.Perfs < 0 || footage.Perfs >= perfsPerFrame ||
+		footage.Frames*perfsPerFrame+footage.Perfs >= perfsPerFoot {
+		return 0, errors.New(_smpte12MOutOfRange)
+	}
+	perfs := footage.Feet*perfsPerFoot + footage.Frames*perfsPerFrame + footage.Perfs
+	if perfs%perfsPerFrame != 0 {
+		if explicitPerfs {
+			return 0, fmt.Errorf("timecode: no frame starts at footage: '%v'", footage)
+		}
+		perfs += perfsPerFrame - perfs%perfsPerFrame
+	}
+	return perfs / perfsPerFrame, nil
+}
+
+// FromFeetFrames Initializes a new instance of the TimeCode struct using a film footage
+// such as "1234+05", or "1+00.2" with a perforation offset, of the film gauge, at the
+// SMPTE framerate.
+func FromFeetFrames(feetFrames string, gauge FilmGauge, rate SmpteFrameRate) (*TimeCode, error) {
+	rec, err := gaugeRecord(gauge)
+	if err != nil {
+		return nil, err
+	}
+	match := _validateFeetFrames.FindStringSubmatch(feetFrames)
+	if match == nil {
+		return nil, fmt.Errorf("timecode: invalid feet and frames: '%s'", feetFrames)
+	}
+	var footage FeetFrames
+	footage.Feet, _ = strconv.ParseInt(match[1], 10, 64)
+	footage.Frames, _ = strconv.ParseInt(match[2], 10, 64)
+	if match[3] != "" {
+		footage.Perfs, _ = strconv.ParseInt(match[3], 10, 64)
+	}
+	framecount, err := feetFramesToFrames(footage, match[3] != "", rec.perfsPerFoot, rec.perfsPerFrame)
+	if err != nil {
+		return nil, err
+	}
+	return FromFrames(framecount, rate)
+}
+
+// FeetFrames Gets the film footage of this instance in the film gauge, from the frames of
+// the frame rate. A negative duration has no footage and returns an error.
+func (m TimeCode) FeetFrames(gauge FilmGauge) (FeetFrames, error) {
+	rec, err := gaugeRecord(gauge)
+	if err != nil {
+		return FeetFrames{}, err
+	}
+	if m.Negative() {
+		return FeetFrames{}, errors.New(_smpte12MMinValueOverflow)
+	}
+	return framesToFeetFrames(m.TotalFrames(), rec.perfsPerFoot, rec.perfsPerFrame), nil
+}
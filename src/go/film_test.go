@@ -0,0 +1,86 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FeetFrames(t *testing.T) {
+	tests := []struct {
+		frames   int64
+		gauge    FilmGauge
+		expected string
+	}{
+		{0, Film35mm4Perf, "0+00"},
+		{16, Film35mm4Perf, "1+00"},
+		{19749, Film35mm4Perf, "1234+05"},
+		{21, Film35mm3Perf, "0+21"},
+		{22, Film35mm3Perf, "1+00.2"},
+		{43, Film35mm3Perf, "2+00.1"},
+		{64, Film35mm3Perf, "3+00"},
+		{33, Film35mm2Perf, "1+01"},
+		{40, Film16mm, "1+00"},
+		{1239, Film16mm, "30+39"},
+	}
+	for _, test := range tests {
+		tc, _ := FromFrames(test.frames, Smpte24)
+		footage, err := tc.FeetFrames(test.gauge)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, footage.String())
+		back, err := FromFeetFrames(test.expected, test.gauge, Smpte24)
+		assert.Nil(t, err)
+		assert.Equal(t, test.frames, back.TotalFrames(), test.expected)
+	}
+	// every frame round trips
+	for _, gauge := range []FilmGauge{Film35mm4Perf, Film35mm3Perf, Film35mm2Perf, Film16mm} {
+		for frames := int64(0); frames < 1000; frames++ {
+			tc, _ := FromFrames(frames, Smpte2398)
+			footage, _ := tc.FeetFrames(gauge)
+			back, err := FromFeetFrames(footage.String(), gauge, Smpte2398)
+			assert.Nil(t, err)
+			assert.Equal(t, frames, back.TotalFrames())
+		}
+	}
+}
+
+func Test_FromFeetFrames(t *testing.T) {
+	tc, err := FromFeetFrames("1234+05", Film35mm4Perf, Smpte24)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:13:42:21", tc.String())
+	// without the perforation offset it is the first frame in the foot
+	tc, err = FromFeetFrames("1+00", Film35mm3Perf, Smpte24)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(22), tc.TotalFrames())
+	// no frame starts there
+	_, err = FromFeetFrames("1+00.1", Film35mm3Perf, Smpte24)
+	assert.NotNil(t, err)
+	// an explicit offset of 0 is not snapped to the next frame
+	_, err = FromFeetFrames("1+00.0", Film35mm3Perf, Smpte24)
+	assert.NotNil(t, err)
+	// out of range
+	for _, test := range []struct {
+		footage string
+		gauge   FilmGauge
+	}{
+		{"0+16", Film35mm4Perf},
+		{"0+15.1", Film35mm4Perf},
+		{"0+15.4", Film35mm4Perf},
+		{"0+21.1", Film35mm3Perf},
+		{"0+32", Film35mm2Perf},
+		{"0+40", Film16mm},
+	} {
+		_, err = FromFeetFrames(test.footage, test.gauge, Smpte24)
+		assert.NotNil(t, err, test.footage)
+	}
+	// bad format
+	for _, footage := range []string{"", "12", "12+", "+05", "-1+00", "1+00.", "1:00"} {
+		_, err = FromFeetFrames(footage, Film35mm4Perf, Smpte24)
+		assert.NotNil(t, err, footage)
+	}
+	_, err = FromFeetFrames("1+00", FilmGauge(9), Smpte24)
+	assert.NotNil(t, err)
+	neg, _ := FromTimeCode("-00:00:01:00", Smpte24)
+	_, err = neg.FeetFrames(Film35mm4Perf)
+	assert.NotNil(t, err)
+}
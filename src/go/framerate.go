@@ -0,0 +1,261 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// FrameRateInfo describes a frame rate, see RegisterFrameRate.
+type FrameRateInfo struct {
+	// Frames is the nominal number of frames per second, the number of frame labels in a second.
+	Frames int64
+	// Numerator and Denominator are the exact number of frames per second, e.g. 30000/1001.
+	Numerator   int64
+	Denominator int64
+	// DropFrame indicates the labels are drop frame timecode.
+	DropFrame bool
+	// DropCount is the number of frame numbers dropped at the start of every minute,
+	// except every tenth minute, e.g. 2 for 29.97 drop frame.
+	DropCount int64
+	// Name is the display name of the frame rate, e.g. "29.97 DF".
+	Name string
+}
+
+// _firstUserFrameRate is the first SmpteFrameRate given to a registered frame rate,
+// leaving room for more built in frame rates.
+const _firstUserFrameRate SmpteFrameRate = 1000
+
+var (
+	// _rateGrammar matches a frame rate string, after spaces are removed and it is lower cased:
+	// a rational or decimal rate, an optional fps, an optional interlaced or progressive scan,
+	// and an optional drop frame or non drop frame suffix.
+	_rateGrammar = regexp.MustCompile(`^(?:(\d+)/(\d+)|(\d+(?:\.\d+)?))(?:fps)?(i|p)?(df|ndf)?$`)
+	// _ntscTolerance is how far a decimal rate may be from an NTSC rate, e.g. 29.97 from 30000/1001.
+	_ntscTolerance = newRat(1, 200)
+	// _rateMutex guards _rateRecords, which grows as frame rates are registered.
+	_rateMutex     sync.RWMutex
+	_nextFrameRate = _firstUserFrameRate
+)
+
+// RegisterFrameRate adds a frame rate to the registry and returns the SmpteFrameRate
+// for it, which can be used like the built in frame rates. Registering a frame rate
+// equal to one already known returns the existing SmpteFrameRate.
+func RegisterFrameRate(info FrameRateInfo) (SmpteFrameRate, error) {
+	if info.Frames <= 0 || info.Numerator <= 0 || info.Denominator <= 0 {
+		return Unknown, fmt.Errorf("timecode: invalid frame rate: '%v'", info)
+	}
+	if info.DropFrame != (info.DropCount > 0) || info.DropCount >= info.Frames {
+		return Unknown, fmt.Errorf("timecode: invalid drop count for frame rate: '%v'", info)
+	}
+	rat := newRat(info.Numerator, info.Denominator)
+	rec := &rateRec{
+		frames:  info.Frames,
+		minutes: info.Frames*60 - info.DropCount,
+		hours:   6 * (info.Frames*600 - 9*info.DropCount),
+		rate:    math.Round(ratFloat64(rat)*100) / 100,
+		drop:    info.DropCount,
+		num:     rat.Num().Int64(),
+		den:     rat.Denom().Int64(),
+		name:    info.Name,
+	}
+	if rec.name == "" {
+		rec.name = fmt.Sprintf("%v", rec.rate)
+		if rec.drop > 0 {
+			rec.name += " DF"
+		}
+	}
+
+	_rateMutex.Lock()
+	defer _rateMutex.Unlock()
+	for rate, r := range _rateRecords {
+		if r.frames == rec.frames && r.drop == rec.drop && r.num == rec.num && r.den == rec.den {
+			return rate, nil
+		}
+	}
+	rate := _nextFrameRate
+	_nextFrameRate++
+	_rateRecords[rate] = rec
+	return rate, nil
+}
+
+// Info returns the description of the frame rate, and false for an unknown frame rate.
+func (r SmpteFrameRate) Info() (FrameRateInfo, bool) {
+	rec, ok := lookupRate(r)
+	if !ok {
+		return FrameRateInfo{}, false
+	}
+	return FrameRateInfo{
+		Frames:      rec.frames,
+		Numerator:   rec.num,
+		Denominator: rec.den,
+		DropFrame:   rec.drop > 0,
+		DropCount:   rec.drop,
+		Name:        rec.name,
+	}, true
+}
+
+// String returns the display name of the frame rate.
+func (r SmpteFrameRate) String() string {
+	if rec, ok := lookupRate(r); ok {
+		return rec.name
+	}
+	if r == Unknown {
+		return "Unknown"
+	}
+	return fmt.Sprintf("SmpteFrameRate(%d)", int(r))
+}
+
+// Rational returns the exact number of frames per second, e.g. 30000/1001 for 29.97,
+// or nil for an unknown frame rate.
+func (r SmpteFrameRate) Rational() *big.Rat {
+	rec, ok := lookupRate(r)
+	if !ok {
+		return nil
+	}
+	return newRat(rec.num, rec.den)
+}
+
+// Nominal returns the nominal number of frames per second, e.g. 30 for 29.97, which is
+// the number of frame labels in a second, or 0 for an unknown frame rate.
+func (r SmpteFrameRate) Nominal() int64 {
+	if rec, ok := lookupRate(r); ok {
+		return rec.frames
+	}
+	return 0
+}
+
+// IsNTSC Indicates whether the frame rate is an NTSC rate, that is a nominal rate
+// slowed by 1000/1001.
+func (r SmpteFrameRate) IsNTSC() bool {
+	rec, ok := lookupRate(r)
+	return ok && rec.den == 1001
+}
+
+// IsDropFrame Indicates whether the frame rate uses drop frame timecode labels.
+func (r SmpteFrameRate) IsDropFrame() bool {
+	rec, ok := lookupRate(r)
+	return ok && rec.drop > 0
+}
+
+// FrameDuration returns the exact duration of one frame in seconds, e.g. 1001/30000
+// for 29.97, or nil for an unknown frame rate.
+func (r SmpteFrameRate) FrameDuration() *big.Rat {
+	rec, ok := lookupRate(r)
+	if !ok {
+		return nil
+	}
+	return newRat(rec.den, rec.num)
+}
+
+// RateFromRational returns the SmpteFrameRate with exactly the number of frames per
+// second and drop frame flag, or Unknown when there is none. When several frame rates
+// match, the one with the lowest value is returned, so built in rates come first.
+func RateFromRational(fps *big.Rat, dropFrame bool) SmpteFrameRate {
+	ret := Unknown
+	if fps == nil {
+		return ret
+	}
+	for rate, rec := range rateRecords() {
+		if (rec.drop > 0) != dropFrame || newRat(rec.num, rec.den).Cmp(fps) != 0 {
+			continue
+		}
+		if ret == Unknown || rate < ret {
+			ret = rate
+		}
+	}
+	return ret
+}
+
+// ParseRate returns the SmpteFrameRate of a frame rate string, such as "25", "50p",
+// "23.976", "23.98", "24000/1001", "29.97DF", "29.97 NDF", "30DF", "59.94i" or "119.88".
+// A decimal within 0.005 of an NTSC rate is taken as the NTSC rate, and an interlaced
+// rate is a field rate, so "59.94i" is 29.97 frames per second. Without a DF suffix the
+// rate is non drop frame.
+func ParseRate(rate string) (SmpteFrameRate, error) {
+	fps, drop, _, err := parseRate(rate)
+	if err != nil {
+		return Unknown, err
+	}
+	ret := RateFromRational(fps, drop)
+	if ret == Unknown {
+		return Unknown, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	return ret, nil
+}
+
+// parseRate Parses a frame rate string for the exact number of frames per second and the
+// drop frame flag, explicit is false when the string has no DF or NDF suffix.
+func parseRate(rate string) (fps *big.Rat, drop, explicit bool, err error) {
+	match := _rateGrammar.FindStringSubmatch(strings.ToLower(strings.Replace(rate, " ", "", -1)))
+	if match == nil {
+		return nil, false, false, fmt.Errorf("timecode: invalid frame rate: '%s'", rate)
+	}
+	fps, ok := new(big.Rat), false
+	if match[3] == "" {
+		fps, ok = fps.SetString(match[1] + "/" + match[2])
+	} else if fps, ok = fps.SetString(match[3]); ok && !fps.IsInt() {
+		// snap to the NTSC rate, nominal*1000/1001
+		nominal := roundRat(new(big.Rat).Mul(fps, newRat(1001, 1000)), 0)
+		ntsc := new(big.Rat).Mul(nominal, newRat(1000, 1001))
+		if new(big.Rat).Abs(new(big.Rat).Sub(fps, ntsc)).Cmp(_ntscTolerance) < 0 {
+			fps = ntsc
+		}
+	}
+	if !ok || fps.Sign() <= 0 {
+		return nil, false, false, fmt.Errorf("timecode: invalid frame rate: '%s'", rate)
+	}
+	if match[4] == "i" {
+		fps.Mul(fps, newRat(1, 2))
+	}
+	return fps, match[5] == "df", match[5] != "", nil
+}
+
+// FormatRate returns the frame rate string of the SmpteFrameRate, which ParseRate parses
+// back to the same frame rate, e.g. "25", "23.98", "29.97DF" or "24000/1001".
+func FormatRate(rate SmpteFrameRate) string {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return rate.String()
+	}
+	ret := fmt.Sprintf("%d/%d", rec.num, rec.den)
+	if rec.den == 1 {
+		ret = fmt.Sprintf("%d", rec.num)
+	} else if rec.den == 1001 && rec.num%1000 == 0 {
+		ret = newRat(rec.num, rec.den).FloatString(2)
+		ret = strings.TrimSuffix(strings.TrimRight(ret, "0"), ".")
+	}
+	if rec.drop > 0 {
+		ret += "DF"
+	}
+	return ret
+}
+
+// lookupRate returns the record of the frame rate, and false for an unknown frame rate.
+func lookupRate(rate SmpteFrameRate) (*rateRec, bool) {
+	_rateMutex.RLock()
+	defer _rateMutex.RUnlock()
+	rec, ok := _rateRecords[rate]
+	return rec, ok
+}
+
+// rateRecord returns the record of a known frame rate. Every TimeCode has a known frame
+// rate, as the constructors check it with lookupRate, so it is only used for one.
+func rateRecord(rate SmpteFrameRate) *rateRec {
+	rec, _ := lookupRate(rate)
+	return rec
+}
+
+// rateRecords returns a copy of the registry, to range over while rates are registered.
+func rateRecords() map[SmpteFrameRate]*rateRec {
+	_rateMutex.RLock()
+	defer _rateMutex.RUnlock()
+	ret := make(map[SmpteFrameRate]*rateRec, len(_rateRecords))
+	for rate, rec := range _rateRecords {
+		ret[rate] = rec
+	}
+	return ret
+}
@@ -0,0 +1,247 @@
+package timecode
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unregisterFrameRate removes a frame rate registered by a test.
+func unregisterFrameRate(rate SmpteFrameRate) {
+	_rateMutex.Lock()
+	defer _rateMutex.Unlock()
+	delete(_rateRecords, rate)
+}
+
+func Test_RegisterFrameRate(t *testing.T) {
+	fps48, err := RegisterFrameRate(FrameRateInfo{Frames: 48, Numerator: 48, Denominator: 1, Name: "48"})
+	assert.Nil(t, err)
+	defer unregisterFrameRate(fps48)
+	assert.True(t, fps48 >= _firstUserFrameRate)
+	assert.Equal(t, "48", fps48.String())
+	// registering again returns the same rate
+	again, err := RegisterFrameRate(FrameRateInfo{Frames: 48, Numerator: 96, Denominator: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, fps48, again)
+	// and a built in rate is found too
+	builtin, err := RegisterFrameRate(FrameRateInfo{Frames: 30, Numerator: 30000, Denominator: 1001, DropFrame: true, DropCount: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte2997Drop, builtin)
+
+	// constructors, String and ticks
+	tc, err := FromFrames(48*61+47, fps48)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:01:47", tc.String())
+	assert.Equal(t, fps48, tc.FrameRate())
+	tc, err = FromTicks27Mhz(27000000/48*49, fps48)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:01", tc.String())
+	tc, err = FromTimeCode("00:00:10:20", fps48)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(500), tc.TotalFrames())
+	// max value
+	max, err := MaxValue(fps48)
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59:47", max.String())
+	_, err = max.PlusFrames(1)
+	assert.NotNil(t, err)
+}
+
+func Test_RegisterDropFrameRate(t *testing.T) {
+	// true 30 fps drop frame, as used by some MTC equipment, is built in
+	df30, err := RegisterFrameRate(FrameRateInfo{Frames: 30, Numerator: 30, Denominator: 1, DropFrame: true, DropCount: 2, Name: "30 DF"})
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte30Drop, df30)
+	assert.Equal(t, "30 DF", df30.String())
+	tc, _ := FromFrames(1800, df30)
+	assert.Equal(t, "00:01:00;02", tc.String())
+	assert.Equal(t, 0, tc.AbsoluteTime().Cmp(big.NewRat(60, 1)))
+	info, ok := df30.Info()
+	assert.True(t, ok)
+	assert.Equal(t, FrameRateInfo{Frames: 30, Numerator: 30, Denominator: 1, DropFrame: true, DropCount: 2, Name: "30 DF"}, info)
+	max, _ := MaxValue(df30)
+	assert.Equal(t, "23:59:59;29", max.String())
+	// the name defaults to the rate
+	fps72, _ := RegisterFrameRate(FrameRateInfo{Frames: 72, Numerator: 72000, Denominator: 1001})
+	defer unregisterFrameRate(fps72)
+	assert.Equal(t, "71.93", fps72.String())
+}
+
+func Test_RegisterFrameRateInvalid(t *testing.T) {
+	for _, info := range []FrameRateInfo{
+		{Frames: 0, Numerator: 24, Denominator: 1},
+		{Frames: 24, Numerator: 0, Denominator: 1},
+		{Frames: 24, Numerator: 24, Denominator: 0},
+		{Frames: 30, Numerator: 30, Denominator: 1, DropFrame: true},
+		{Frames: 30, Numerator: 30, Denominator: 1, DropCount: 2},
+		{Frames: 30, Numerator: 30, Denominator: 1, DropFrame: true, DropCount: 30},
+	} {
+		rate, err := RegisterFrameRate(info)
+		assert.NotNil(t, err)
+		assert.Equal(t, Unknown, rate)
+	}
+	_, err := MaxValue(SmpteFrameRate(999))
+	assert.NotNil(t, err)
+	assert.Equal(t, "SmpteFrameRate(999)", SmpteFrameRate(999).String())
+	assert.Equal(t, "Unknown", Unknown.String())
+	assert.Equal(t, "29.97 NDF", Smpte2997NonDrop.String())
+}
+
+func Test_RegisterFrameRateConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	rates := make([]SmpteFrameRate, 8)
+	for i := range rates {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rates[i], _ = RegisterFrameRate(FrameRateInfo{Frames: 36, Numerator: 36, Denominator: 1})
+			tc, _ := FromFrames(int64(i), Smpte25)
+			_ = tc.String()
+		}(i)
+	}
+	wg.Wait()
+	defer unregisterFrameRate(rates[0])
+	for _, rate := range rates {
+		assert.Equal(t, rates[0], rate)
+	}
+}
+
+func Test_FrameRateRational(t *testing.T) {
+	tests := []struct {
+		rate     SmpteFrameRate
+		rational string
+		nominal  int64
+		ntsc     bool
+		drop     bool
+	}{
+		{Smpte2398, "24000/1001", 24, true, false},
+		{Smpte24, "24", 24, false, false},
+		{Smpte25, "25", 25, false, false},
+		{Smpte2997Drop, "30000/1001", 30, true, true},
+		{Smpte2997NonDrop, "30000/1001", 30, true, false},
+		{Smpte30, "30", 30, false, false},
+		{Smpte30Drop, "30", 30, false, true},
+		{Smpte4795, "48000/1001", 48, true, false},
+		{Smpte5994Drop, "60000/1001", 60, true, true},
+		{Smpte9590, "96000/1001", 96, true, false},
+		{Smpte100, "100", 100, false, false},
+		{Smpte11988Drop, "120000/1001", 120, true, true},
+		{Smpte11988NonDrop, "120000/1001", 120, true, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.rational, test.rate.Rational().RatString(), test.rate.String())
+		assert.Equal(t, test.nominal, test.rate.Nominal(), test.rate.String())
+		assert.Equal(t, test.ntsc, test.rate.IsNTSC(), test.rate.String())
+		assert.Equal(t, test.drop, test.rate.IsDropFrame(), test.rate.String())
+		duration := new(big.Rat).Inv(test.rate.Rational())
+		assert.Equal(t, 0, duration.Cmp(test.rate.FrameDuration()), test.rate.String())
+		assert.Equal(t, test.rate, RateFromRational(test.rate.Rational(), test.drop), test.rate.String())
+	}
+	// the returned rational is a copy
+	r := Smpte25.Rational()
+	r.SetInt64(1)
+	assert.Equal(t, "25", Smpte25.Rational().RatString())
+	// unknown
+	assert.Nil(t, Unknown.Rational())
+	assert.Nil(t, Unknown.FrameDuration())
+	assert.Equal(t, int64(0), Unknown.Nominal())
+	assert.False(t, Unknown.IsNTSC())
+	assert.False(t, Unknown.IsDropFrame())
+	assert.Equal(t, Unknown, RateFromRational(big.NewRat(25, 1), true))
+	assert.Equal(t, Smpte30Drop, RateFromRational(big.NewRat(30, 1), true))
+	assert.Equal(t, Unknown, RateFromRational(big.NewRat(2997, 100), false))
+	assert.Equal(t, Unknown, RateFromRational(nil, false))
+	// equal rationals in another form
+	assert.Equal(t, Smpte2997NonDrop, RateFromRational(big.NewRat(60000, 2002), false))
+}
+
+func Test_ParseRate(t *testing.T) {
+	tests := []struct {
+		rate     string
+		expected SmpteFrameRate
+	}{
+		{"23.976", Smpte2398},
+		{"23.98", Smpte2398},
+		{"24000/1001", Smpte2398},
+		{"24", Smpte24},
+		{"24fps", Smpte24},
+		{"25", Smpte25},
+		{"50i", Smpte25},
+		{"50p", Smpte50},
+		{"29.97", Smpte2997NonDrop},
+		{"29.97DF", Smpte2997Drop},
+		{"29.97NDF", Smpte2997NonDrop},
+		{"29.97 DF", Smpte2997Drop},
+		{"29.97 NDF", Smpte2997NonDrop},
+		{"30000/1001df", Smpte2997Drop},
+		{"59.94i", Smpte2997NonDrop},
+		{"59.94iDF", Smpte2997Drop},
+		{"59.94", Smpte5994NonDrop},
+		{"59.94p DF", Smpte5994Drop},
+		{"47.952", Smpte4795},
+		{"95.904", Smpte9590},
+		{"119.88", Smpte11988NonDrop},
+		{"119.88DF", Smpte11988Drop},
+		{"120", Smpte120},
+		{"30DF", Smpte30Drop},
+		{"30 DF", Smpte30Drop},
+	}
+	for _, test := range tests {
+		rate, err := ParseRate(test.rate)
+		assert.Nil(t, err, test.rate)
+		assert.Equal(t, test.expected, rate, test.rate)
+	}
+	for _, rate := range []string{"", "abc", "29.97XDF", "1001/0", "0", "-25", "12", "29.97.1", "25/1/1"} {
+		ret, err := ParseRate(rate)
+		assert.NotNil(t, err, rate)
+		assert.Equal(t, Unknown, ret, rate)
+	}
+}
+
+func Test_FormatRateRoundTrip(t *testing.T) {
+	assert.Equal(t, "23.98", FormatRate(Smpte2398))
+	assert.Equal(t, "29.97DF", FormatRate(Smpte2997Drop))
+	assert.Equal(t, "29.97", FormatRate(Smpte2997NonDrop))
+	assert.Equal(t, "95.9", FormatRate(Smpte9590))
+	assert.Equal(t, "119.88DF", FormatRate(Smpte11988Drop))
+	assert.Equal(t, "30DF", FormatRate(Smpte30Drop))
+	odd, _ := RegisterFrameRate(FrameRateInfo{Frames: 34, Numerator: 100, Denominator: 3})
+	defer unregisterFrameRate(odd)
+	assert.Equal(t, "100/3", FormatRate(odd))
+
+	for rate := range rateRecords() {
+		parsed, err := ParseRate(FormatRate(rate))
+		assert.Nil(t, err, FormatRate(rate))
+		assert.Equal(t, rate, parsed, FormatRate(rate))
+		// and timecode@rate strings
+		tc, _ := FromFrames(rate.Nominal()*3661+rate.Nominal()-1, rate)
+		back, err := FromTimeCodeRate(tc.StringWithRate())
+		assert.Nil(t, err, tc.StringWithRate())
+		assert.Equal(t, rate, back.FrameRate(), tc.StringWithRate())
+		assert.Equal(t, 0, tc.Cmp(*back), tc.StringWithRate())
+	}
+}
+
+func Test_FromTimeCodeRateGrammar(t *testing.T) {
+	tc, err := FromTimeCodeRate("01:00:00:00@29.97DF")
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte2997Drop, tc.FrameRate())
+	assert.Equal(t, "01:00:00;00@29.97DF", tc.StringWithRate())
+	tc, err = FromTimeCodeRate("01:00:00;00@29.97NDF")
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte2997NonDrop, tc.FrameRate())
+	tc, err = FromTimeCodeRate("00:00:10:00@24000/1001")
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte2398, tc.FrameRate())
+	// the default rate is 29.97
+	tc, err = FromTimeCodeRate("00:00:01;01")
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte2997Drop, tc.FrameRate())
+	tc, err = FromTimeCodeRate("00:00:01:01")
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte2997NonDrop, tc.FrameRate())
+	_, err = FromTimeCodeRate("00:00:01:01@25@25")
+	assert.NotNil(t, err)
+}
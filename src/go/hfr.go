@@ -0,0 +1,83 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// _maxPairRate is the highest nominal frame rate ST 12-1 timecode can carry.
+const _maxPairRate = 30
+
+// FramePair is the SMPTE ST 12-3 representation of a high frame rate timecode, for
+// interfaces that only carry ST 12-1 timecode of at most 30 fps: a label at the base
+// frame rate, plus the index of the frame within the label. At 50, 59.94 and 60 fps
+// every label is a frame pair, and the index is the frame pair flag, 0 or 1. At 100,
+// 119.88 and 120 fps every label holds 4 frames, and the index is 0 to 3.
+type FramePair struct {
+	// Label is the timecode at the base frame rate, e.g. 29.97 DF for 59.94 DF.
+	Label TimeCode
+	// Index is the frame within the label, from 0 to the frames per label less 1.
+	Index int
+}
+
+// PairBase returns the base frame rate of a high frame rate, and the number of frames
+// of the high frame rate in each label of the base frame rate, e.g. 29.97 DF and 2 for
+// 59.94 DF. A frame rate of at most 30 fps is its own base, with 1 frame per label.
+func PairBase(rate SmpteFrameRate) (base SmpteFrameRate, frames int64, err error) {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return Unknown, 0, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	frames = 1
+	for rec.frames/frames > _maxPairRate {
+		frames *= 2
+	}
+	if rec.frames%frames != 0 || rec.drop%frames != 0 {
+		return Unknown, 0, fmt.Errorf("timecode: frame rate has no ST 12-3 base rate: '%v'", rate)
+	}
+	base = RateFromRational(newRat(rec.num, rec.den*frames), rec.drop > 0)
+	if base == Unknown || rateRecord(base).drop != rec.drop/frames {
+		return Unknown, 0, fmt.Errorf("timecode: frame rate has no ST 12-3 base rate: '%v'", rate)
+	}
+	return base, frames, nil
+}
+
+// FramePair returns the ST 12-3 representation of this instance, the label at the base
+// frame rate of the frame containing this instance, and the index of the frame in it.
+// ST 12-1 timecode has no negative values, so a negative duration returns an error.
+func (m TimeCode) FramePair() (FramePair, error) {
+	base, frames, err := PairBase(m.frameRate)
+	if err != nil {
+		return FramePair{}, err
+	}
+	if m.Negative() {
+		return FramePair{}, errors.New(_smpte12MMinValueOverflow)
+	}
+	framecount := m.TotalFrames()
+	label := m
+	label.frameRate = base
+	label.absoluteTime = framesToAbsoluteTime(framecount/frames, base)
+	return FramePair{Label: label, Index: int(framecount % frames)}, nil
+}
+
+// FromFramePair Initializes a new instance of the TimeCode struct at a high frame rate
+// from its ST 12-3 representation. The label must be at the base frame rate of the rate.
+func FromFramePair(pair FramePair, rate SmpteFrameRate) (*TimeCode, error) {
+	base, frames, err := PairBase(rate)
+	if err != nil {
+		return nil, err
+	}
+	if pair.Label.frameRate != base {
+		return nil, fmt.Errorf("timecode: frame pair label is not at the base frame rate: '%v'", base)
+	}
+	if pair.Index < 0 || int64(pair.Index) >= frames {
+		return nil, fmt.Errorf("timecode: frame pair index is out of range: '%v'", pair.Index)
+	}
+	if pair.Label.Negative() {
+		return nil, errors.New(_smpte12MMinValueOverflow)
+	}
+	ret := pair.Label
+	ret.frameRate = rate
+	ret.absoluteTime = framesToAbsoluteTime(pair.Label.TotalFrames()*frames+int64(pair.Index), rate)
+	return &ret, nil
+}
@@ -0,0 +1,105 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PairBase(t *testing.T) {
+	tests := []struct {
+		rate   SmpteFrameRate
+		base   SmpteFrameRate
+		frames int64
+	}{
+		{Smpte25, Smpte25, 1},
+		{Smpte2997Drop, Smpte2997Drop, 1},
+		{Smpte4795, Smpte2398, 2},
+		{Smpte50, Smpte25, 2},
+		{Smpte5994Drop, Smpte2997Drop, 2},
+		{Smpte5994NonDrop, Smpte2997NonDrop, 2},
+		{Smpte60, Smpte30, 2},
+		{Smpte96, Smpte24, 4},
+		{Smpte9590, Smpte2398, 4},
+		{Smpte100, Smpte25, 4},
+		{Smpte11988Drop, Smpte2997Drop, 4},
+		{Smpte11988NonDrop, Smpte2997NonDrop, 4},
+		{Smpte120, Smpte30, 4},
+	}
+	for _, test := range tests {
+		base, frames, err := PairBase(test.rate)
+		assert.Nil(t, err, test.rate.String())
+		assert.Equal(t, test.base, base, test.rate.String())
+		assert.Equal(t, test.frames, frames, test.rate.String())
+	}
+	_, _, err := PairBase(Unknown)
+	assert.NotNil(t, err)
+	// 72 fps has no ST 12-1 base rate
+	fps72, _ := RegisterFrameRate(FrameRateInfo{Frames: 72, Numerator: 72, Denominator: 1})
+	defer unregisterFrameRate(fps72)
+	_, _, err = PairBase(fps72)
+	assert.NotNil(t, err)
+}
+
+func Test_FramePair(t *testing.T) {
+	tc, _ := FromTimeCode("01:00:00;45", Smpte5994Drop)
+	pair, err := tc.FramePair()
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;22", pair.Label.String())
+	assert.Equal(t, Smpte2997Drop, pair.Label.FrameRate())
+	assert.Equal(t, 1, pair.Index)
+	back, err := FromFramePair(pair, Smpte5994Drop)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;45", back.String())
+	// the first frames after a drop frame minute
+	tc, _ = FromTimeCode("00:01:00;04", Smpte5994Drop)
+	pair, _ = tc.FramePair()
+	assert.Equal(t, "00:01:00;02", pair.Label.String())
+	assert.Equal(t, 0, pair.Index)
+	tc, _ = FromTimeCode("00:01:00;11", Smpte11988Drop)
+	pair, _ = tc.FramePair()
+	assert.Equal(t, "00:01:00;02", pair.Label.String())
+	assert.Equal(t, 3, pair.Index)
+	tc, _ = FromTimeCode("10:20:30:119", Smpte120)
+	pair, _ = tc.FramePair()
+	assert.Equal(t, "10:20:30:29", pair.Label.String())
+	assert.Equal(t, 3, pair.Index)
+	// the pair label starts at the first frame of the pair
+	tc, _ = FromTimeCode("00:00:01:49", Smpte50)
+	pair, _ = tc.FramePair()
+	first, _ := FromTimeCode("00:00:01:48", Smpte50)
+	assert.Equal(t, 0, pair.Label.AbsoluteTime().Cmp(first.AbsoluteTime()))
+
+	// every frame round trips, across drop frame minutes
+	for _, rate := range []SmpteFrameRate{Smpte5994Drop, Smpte11988Drop, Smpte100, Smpte9590} {
+		for framecount := int64(0); framecount < 3*rate.Nominal()*60; framecount++ {
+			tc, _ := FromFrames(framecount, rate)
+			pair, err := tc.FramePair()
+			assert.Nil(t, err)
+			// the label of the high frame rate is the base label, with the frames times the frames per label plus the index
+			_, frames, _ := PairBase(rate)
+			assert.Equal(t, tc.FramesSegment(), pair.Label.FramesSegment()*frames+int64(pair.Index))
+			assert.Equal(t, tc.SecondsSegment(), pair.Label.SecondsSegment())
+			assert.Equal(t, tc.MinutesSegment(), pair.Label.MinutesSegment())
+			back, err := FromFramePair(pair, rate)
+			assert.Nil(t, err)
+			assert.Equal(t, framecount, back.TotalFrames())
+		}
+	}
+}
+
+func Test_FramePairErrors(t *testing.T) {
+	tc, _ := FromTimeCode("-00:00:01:10", Smpte50)
+	_, err := tc.FramePair()
+	assert.NotNil(t, err)
+	label, _ := FromTimeCode("00:00:01:10", Smpte25)
+	_, err = FromFramePair(FramePair{Label: *label, Index: 2}, Smpte50)
+	assert.NotNil(t, err)
+	_, err = FromFramePair(FramePair{Label: *label, Index: -1}, Smpte50)
+	assert.NotNil(t, err)
+	_, err = FromFramePair(FramePair{Label: *label, Index: 1}, Smpte60)
+	assert.NotNil(t, err)
+	v, err := FromFramePair(FramePair{Label: *label, Index: 3}, Smpte100)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:43", v.String())
+}
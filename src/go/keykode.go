@@ -0,0 +1,144 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// _maxKeyCount is the largest key number count of a KeyKode, which has 4 digits.
+const _maxKeyCount = 9999
+
+// _validateKeyKode Regular expression object used for parsing KeyKode, e.g.
+// "KU 23 1234 5678+12", with an optional perforation offset, e.g. "KU 23 1234 5678+12.2".
+var _validateKeyKode = regexp.MustCompile(`^([A-Z]{2})\s*(\d{2})\s*(\d{4})\s*(\d{4})\s*\+\s*(\d{1,2})(?:\.(\d))?$`)
+
+// KeyKode is a Kodak KeyKode film edge code, e.g. "KU 23 1234 5678+12": the manufacturer
+// and film code, a prefix of the film identifier and roll number, the count of the key
+// number printed on the edge of the film, and the frames from the key number.
+type KeyKode struct {
+	// Manufacturer is the manufacturer and film code, e.g. "KU".
+	Manufacturer string
+	// Prefix is the 2 digit film identifier and the 4 digit roll number, e.g. "231234".
+	Prefix string
+	// Count is the key number, from 0 to 9999.
+	Count int64
+	// Frames is the frames from the key number.
+	Frames int64
+	// Perfs is the perforation offset of the frame from the start of frame number Frames,
+	// see FeetFrames.
+	Perfs int64
+}
+
+// ParseKeyKode Parses a KeyKode string such as "KU 23 1234 5678+12".
+func ParseKeyKode(keyKode string) (KeyKode, error) {
+	match := _validateKeyKode.FindStringSubmatch(keyKode)
+	if match == nil {
+		return KeyKode{}, fmt.Errorf("timecode: invalid keykode: '%s'", keyKode)
+	}
+	ret := KeyKode{Manufacturer: match[1], Prefix: match[2] + match[3]}
+	ret.Count, _ = strconv.ParseInt(match[4], 10, 64)
+	ret.Frames, _ = strconv.ParseInt(match[5], 10, 64)
+	if match[6] != "" {
+		ret.Perfs, _ = strconv.ParseInt(match[6], 10, 64)
+	}
+	return ret, nil
+}
+
+// String returns the KeyKode in the format "KU 23 1234 5678+12", with the perforation
+// offset when it is not zero.
+func (k KeyKode) String() string {
+	prefix := k.Prefix
+	if len(prefix) == 6 {
+		prefix = prefix[:2] + " " + prefix[2:]
+	}
+	ret := fmt.Sprintf("%s %s %04d+%02d", k.Manufacturer, prefix, k.Count, k.Frames)
+	if k.Perfs != 0 {
+		ret += fmt.Sprintf(".%d", k.Perfs)
+	}
+	return ret
+}
+
+// frames Returns the number of frames of the film gauge from key number 0 of the roll.
+func (k KeyKode) frames(rec *gaugeRec) (int64, error) {
+	return feetFramesToFrames(FeetFrames{Feet: k.Count, Frames: k.Frames, Perfs: k.Perfs}, k.Perfs != 0, rec.perfsPerKey, rec.perfsPerFrame)
+}
+
+// withFrames Returns a KeyKode of the same roll at a number of frames from key number 0.
+func (k KeyKode) withFrames(framecount int64, rec *gaugeRec) (KeyKode, error) {
+	if framecount < 0 {
+		return k, errors.New(_smpte12MMinValueOverflow)
+	}
+	footage := framesToFeetFrames(framecount, rec.perfsPerKey, rec.perfsPerFrame)
+	if footage.Feet > _maxKeyCount {
+		return k, fmt.Errorf("timecode: keykode count is out of range: '%v'", footage.Feet)
+	}
+	k.Count, k.Frames, k.Perfs = footage.Feet, footage.Frames, footage.Perfs
+	return k, nil
+}
+
+// PlusFrames returns a new KeyKode that is this instance plus a number of frames of the
+// film gauge.
+func (k KeyKode) PlusFrames(frames int64, gauge FilmGauge) (KeyKode, error) {
+	rec, err := gaugeRecord(gauge)
+	if err != nil {
+		return k, err
+	}
+	framecount, err := k.frames(rec)
+	if err != nil {
+		return k, err
+	}
+	return k.withFrames(framecount+frames, rec)
+}
+
+// MinusFrames returns a new KeyKode that is this instance minus a number of frames of the
+// film gauge.
+func (k KeyKode) MinusFrames(frames int64, gauge FilmGauge) (KeyKode, error) {
+	return k.PlusFrames(-frames, gauge)
+}
+
+// SameRoll Indicates whether two KeyKode are on the same roll of film, with the same
+// manufacturer and prefix.
+func (k KeyKode) SameRoll(other KeyKode) bool {
+	return k.Manufacturer == other.Manufacturer && k.Prefix == other.Prefix
+}
+
+// KeyKodeSync is a sync point between KeyKode and TimeCode, the KeyKode of the frame at
+// a known TimeCode, e.g. from a telecine log. Frames of film and of the timecode are
+// one to one, e.g. 24 fps film at Smpte24 or Smpte2398.
+type KeyKodeSync struct {
+	KeyKode  KeyKode
+	TimeCode TimeCode
+	Gauge    FilmGauge
+}
+
+// ToTimeCode returns the TimeCode of the frame of the KeyKode, which must be on the same
+// roll as the sync point.
+func (s KeyKodeSync) ToTimeCode(k KeyKode) (TimeCode, error) {
+	if !k.SameRoll(s.KeyKode) {
+		return s.TimeCode, fmt.Errorf("timecode: keykode is not on the roll of the sync point: '%v'", k)
+	}
+	rec, err := gaugeRecord(s.Gauge)
+	if err != nil {
+		return s.TimeCode, err
+	}
+	frames, err := k.frames(rec)
+	if err != nil {
+		return s.TimeCode, err
+	}
+	syncFrames, err := s.KeyKode.frames(rec)
+	if err != nil {
+		return s.TimeCode, err
+	}
+	return s.TimeCode.PlusFrames(frames - syncFrames)
+}
+
+// ToKeyKode returns the KeyKode of the frame of the TimeCode, which must be at the frame
+// rate of the sync point.
+func (s KeyKodeSync) ToKeyKode(tc TimeCode) (KeyKode, error) {
+	if tc.FrameRate() != s.TimeCode.FrameRate() {
+		return s.KeyKode, fmt.Errorf("timecode: timecode is not at the frame rate of the sync point: '%v'", tc.FrameRate())
+	}
+	return s.KeyKode.PlusFrames(tc.TotalFrames()-s.TimeCode.TotalFrames(), s.Gauge)
+}
@@ -0,0 +1,83 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseKeyKode(t *testing.T) {
+	k, err := ParseKeyKode("KU 23 1234 5678+12")
+	assert.Nil(t, err)
+	assert.Equal(t, KeyKode{Manufacturer: "KU", Prefix: "231234", Count: 5678, Frames: 12}, k)
+	assert.Equal(t, "KU 23 1234 5678+12", k.String())
+	k, err = ParseKeyKode("KW222345 0012+1.2")
+	assert.Nil(t, err)
+	assert.Equal(t, "KW 22 2345 0012+01.2", k.String())
+	for _, keyKode := range []string{"", "KU 23 1234 5678", "ku 23 1234 5678+12", "KU 23 123 5678+12", "KU 23 1234 5678+123", "K 23 1234 5678+12"} {
+		_, err = ParseKeyKode(keyKode)
+		assert.NotNil(t, err, keyKode)
+	}
+}
+
+func Test_KeyKodeArithmetic(t *testing.T) {
+	k, _ := ParseKeyKode("KU 23 1234 5678+12")
+	v, err := k.PlusFrames(4, Film35mm4Perf)
+	assert.Nil(t, err)
+	assert.Equal(t, "KU 23 1234 5679+00", v.String())
+	v, err = v.MinusFrames(17, Film35mm4Perf)
+	assert.Nil(t, err)
+	assert.Equal(t, "KU 23 1234 5677+15", v.String())
+	// 16mm key numbers are every 20 frames
+	k, _ = ParseKeyKode("KL 44 0001 0100+19")
+	v, _ = k.PlusFrames(1, Film16mm)
+	assert.Equal(t, "KL 44 0001 0101+00", v.String())
+	// 3-perf frames do not always start on a key number
+	k, _ = ParseKeyKode("KU 23 1234 0000+21")
+	v, _ = k.PlusFrames(1, Film35mm3Perf)
+	assert.Equal(t, "KU 23 1234 0001+00.2", v.String())
+	v, _ = v.PlusFrames(21, Film35mm3Perf)
+	assert.Equal(t, "KU 23 1234 0002+00.1", v.String())
+	// out of range
+	k, _ = ParseKeyKode("KU 23 1234 0000+03")
+	_, err = k.MinusFrames(4, Film35mm4Perf)
+	assert.NotNil(t, err)
+	k, _ = ParseKeyKode("KU 23 1234 9999+15")
+	_, err = k.PlusFrames(1, Film35mm4Perf)
+	assert.NotNil(t, err)
+	k, _ = ParseKeyKode("KU 23 1234 0000+16")
+	_, err = k.PlusFrames(1, Film35mm4Perf)
+	assert.NotNil(t, err)
+	_, err = k.PlusFrames(1, FilmGauge(-1))
+	assert.NotNil(t, err)
+}
+
+func Test_KeyKodeSync(t *testing.T) {
+	k, _ := ParseKeyKode("KU 23 1234 5678+12")
+	tc, _ := FromTimeCode("01:00:00:00", Smpte24)
+	sync := KeyKodeSync{KeyKode: k, TimeCode: *tc, Gauge: Film35mm4Perf}
+	// a foot later
+	later, _ := ParseKeyKode("KU 23 1234 5679+12")
+	v, err := sync.ToTimeCode(later)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:16", v.String())
+	earlier, _ := ParseKeyKode("KU 23 1234 5677+00")
+	v, err = sync.ToTimeCode(earlier)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:59:58:20", v.String())
+	// and back
+	keyKode, err := sync.ToKeyKode(v)
+	assert.Nil(t, err)
+	assert.Equal(t, earlier, keyKode)
+	tc, _ = FromTimeCode("01:00:10:00", Smpte24)
+	keyKode, err = sync.ToKeyKode(*tc)
+	assert.Nil(t, err)
+	assert.Equal(t, "KU 23 1234 5693+12", keyKode.String())
+	// another roll or frame rate
+	other, _ := ParseKeyKode("KU 23 9999 5679+12")
+	_, err = sync.ToTimeCode(other)
+	assert.NotNil(t, err)
+	tc, _ = FromTimeCode("01:00:10:00", Smpte25)
+	_, err = sync.ToKeyKode(*tc)
+	assert.NotNil(t, err)
+}
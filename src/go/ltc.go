@@ -0,0 +1,213 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UserBits are the 32 user bits of a SMPTE ST 12-1 timecode frame, as 8 groups of 4 bits.
+// User bits group 1 is the lowest 4 bits and group 8 the highest.
+type UserBits uint32
+
+// Group Returns the user bits group, from 1 to 8.
+func (u UserBits) Group(group int) uint8 {
+	return uint8(u>>(uint(group-1)*4)) & 0xf
+}
+
+// LTCFlags are the flag bits of a LTC frame set by the user, besides the drop frame flag
+// and the biphase mark polarity correction bit, which are set by the encoder.
+type LTCFlags struct {
+	// ColorFrame is the color frame flag.
+	ColorFrame bool
+	// BinaryGroup are the binary group flags BGF0, BGF1 and BGF2, which give the format
+	// of the user bits.
+	BinaryGroup [3]bool
+}
+
+// LTCFrame is a SMPTE ST 12-1 linear timecode frame of 80 bits, bit 0 first. Bit n is bit
+// n%8 of byte n/8, so the sync word is bytes 0xFC 0xBF.
+type LTCFrame [10]byte
+
+// ltcLayout is the position of the flag bits that differ between 25 fps and 30 fps.
+type ltcLayout struct {
+	polarity    uint
+	binaryGroup [3]uint
+}
+
+var (
+	_ltcLayout30 = ltcLayout{polarity: 27, binaryGroup: [3]uint{43, 58, 59}}
+	_ltcLayout25 = ltcLayout{polarity: 59, binaryGroup: [3]uint{27, 58, 43}}
+)
+
+const (
+	_ltcDropFrameBit  = 10
+	_ltcColorFrameBit = 11
+	// _ltcSyncWord is the sync word in bits 64 to 79, 0011 1111 1111 1101 in bit order
+	_ltcSyncWord = 0xBFFC
+)
+
+// _ltcUserBits is the position of each group of user bits.
+var _ltcUserBits = [8]uint{4, 12, 20, 28, 36, 44, 52, 60}
+
+// ltcRateLayout Returns the flag layout of a frame rate that LTC can carry, 25 fps or the
+// 30 fps layout used for 24, 29.97 and 30 fps.
+func ltcRateLayout(rate SmpteFrameRate) (ltcLayout, error) {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return ltcLayout{}, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	if rec.frames > _maxPairRate {
+		return ltcLayout{}, fmt.Errorf("timecode: frame rate is not carried by LTC, see FramePair: '%v'", rate)
+	}
+	if rec.frames == 25 {
+		return _ltcLayout25, nil
+	}
+	return _ltcLayout30, nil
+}
+
+// bits Returns the width bits of the frame from the bit position.
+func (f *LTCFrame) bits(pos, width uint) int {
+	ret := 0
+	for i := uint(0); i < width; i++ {
+		if f[(pos+i)/8]&(1<<((pos+i)%8)) != 0 {
+			ret |= 1 << i
+		}
+	}
+	return ret
+}
+
+// setBits Sets the width bits of the frame from the bit position to the value.
+func (f *LTCFrame) setBits(pos, width uint, value int) {
+	for i := uint(0); i < width; i++ {
+		if value&(1<<i) != 0 {
+			f[(pos+i)/8] |= 1 << ((pos + i) % 8)
+		} else {
+			f[(pos+i)/8] &^= 1 << ((pos + i) % 8)
+		}
+	}
+}
+
+// setBCD Sets the units and tens of a value at the bit positions of the units and tens.
+func (f *LTCFrame) setBCD(units, tens, tensWidth uint, value int64) {
+	f.setBits(units, 4, int(value%10))
+	f.setBits(tens, tensWidth, int(value/10))
+}
+
+// bcd Returns the value of the units and tens at the bit positions of the units and tens.
+func (f *LTCFrame) bcd(units, tens, tensWidth uint) (int64, error) {
+	u, t := f.bits(units, 4), f.bits(tens, tensWidth)
+	if u > 9 {
+		return 0, errors.New(_smpte12MBadFormat)
+	}
+	return int64(t*10 + u), nil
+}
+
+// ones Returns the number of bits of the frame that are 1.
+func (f *LTCFrame) ones() int {
+	ret := 0
+	for _, b := range f {
+		for ; b != 0; b &= b - 1 {
+			ret++
+		}
+	}
+	return ret
+}
+
+// EncodeLTC Encodes the timecode and user bits as a LTC frame, with no flags set besides
+// drop frame.
+func EncodeLTC(tc TimeCode, userBits UserBits) (LTCFrame, error) {
+	return EncodeLTCFlags(tc, userBits, LTCFlags{})
+}
+
+// EncodeLTCFlags Encodes the timecode, user bits and flags as a LTC frame. The timecode
+// must be from 00:00:00:00 to 23:59:59:29 at a frame rate of at most 30 fps. The biphase
+// mark polarity correction bit is set so that every frame has an even number of zeros.
+func EncodeLTCFlags(tc TimeCode, userBits UserBits, flags LTCFlags) (LTCFrame, error) {
+	var ret LTCFrame
+	layout, err := ltcRateLayout(tc.FrameRate())
+	if err != nil {
+		return ret, err
+	}
+	days, hours, minutes, seconds, frames := tc.segments()
+	if tc.Negative() {
+		return ret, errors.New(_smpte12MMinValueOverflow)
+	}
+	if days != 0 {
+		return ret, fmt.Errorf(_smpte12MMaxValueOverflow, tc.TotalSecondsPrecision(), ratFloat64(maxValue(tc.FrameRate())))
+	}
+	ret.setBCD(0, 8, 2, frames)
+	ret.setBCD(16, 24, 3, seconds)
+	ret.setBCD(32, 40, 3, minutes)
+	ret.setBCD(48, 56, 2, hours)
+	for group, pos := range _ltcUserBits {
+		ret.setBits(pos, 4, int(userBits.Group(group+1)))
+	}
+	if tc.FrameRate().IsDropFrame() {
+		ret.setBits(_ltcDropFrameBit, 1, 1)
+	}
+	if flags.ColorFrame {
+		ret.setBits(_ltcColorFrameBit, 1, 1)
+	}
+	for i, flag := range flags.BinaryGroup {
+		if flag {
+			ret.setBits(layout.binaryGroup[i], 1, 1)
+		}
+	}
+	ret.setBits(64, 16, _ltcSyncWord)
+	// an even number of ones in 80 bits is an even number of zeros
+	ret.setBits(layout.polarity, 1, ret.ones()%2)
+	return ret, nil
+}
+
+// DecodeLTC Decodes a LTC frame at the frame rate, which a single frame cannot tell apart
+// between 24, 25 and 30 fps. The drop frame flag of the frame selects the drop frame or
+// non drop frame variant of the rate, e.g. Smpte2997Drop for Smpte2997NonDrop.
+func DecodeLTC(frame LTCFrame, rate SmpteFrameRate) (*TimeCode, UserBits, error) {
+	if _, err := ltcRateLayout(rate); err != nil {
+		return nil, 0, err
+	}
+	if frame.bits(64, 16) != _ltcSyncWord {
+		return nil, 0, errors.New("timecode: LTC frame has no sync word")
+	}
+	if drop := frame.bits(_ltcDropFrameBit, 1) == 1; drop != rate.IsDropFrame() {
+		rate = RateFromRational(rate.Rational(), drop)
+		if rate == Unknown {
+			return nil, 0, errors.New("timecode: LTC drop frame flag does not match the frame rate")
+		}
+	}
+	var segments [4]int64
+	for i, pos := range [4][3]uint{{48, 56, 2}, {32, 40, 3}, {16, 24, 3}, {0, 8, 2}} {
+		value, err := frame.bcd(pos[0], pos[1], pos[2])
+		if err != nil {
+			return nil, 0, err
+		}
+		segments[i] = value
+	}
+	var userBits UserBits
+	for group, pos := range _ltcUserBits {
+		userBits |= UserBits(frame.bits(pos, 4)) << (uint(group) * 4)
+	}
+	if err := validateSegments(0, segments[0], segments[1], segments[2], segments[3], rate); err != nil {
+		return nil, 0, err
+	}
+	if segments[3] < droppedFrames(segments[1], segments[2], rate) {
+		label := fmt.Sprintf("%02d:%02d:%02d;%02d", segments[0], segments[1], segments[2], segments[3])
+		return nil, 0, fmt.Errorf(_smpte12MDroppedFrame, label, rate)
+	}
+	framecount := segmentsToFrames(0, segments[0], segments[1], segments[2], segments[3], rate)
+	tc, err := fromAbsoluteTime(framesToAbsoluteTime(framecount, rate), rate)
+	return tc, userBits, err
+}
+
+// Flags Returns the color frame and binary group flags of the frame, at the frame rate.
+func (f LTCFrame) Flags(rate SmpteFrameRate) (LTCFlags, error) {
+	layout, err := ltcRateLayout(rate)
+	if err != nil {
+		return LTCFlags{}, err
+	}
+	ret := LTCFlags{ColorFrame: f.bits(_ltcColorFrameBit, 1) == 1}
+	for i, pos := range layout.binaryGroup {
+		ret.BinaryGroup[i] = f.bits(pos, 1) == 1
+	}
+	return ret, nil
+}
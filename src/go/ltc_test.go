@@ -0,0 +1,117 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EncodeLTC(t *testing.T) {
+	// only the sync word and the polarity correction bit are set at 00:00:00:00
+	tc, _ := FromTimeCode("00:00:00:00", Smpte30)
+	frame, err := EncodeLTC(*tc, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, LTCFrame{0, 0, 0, 0x08, 0, 0, 0, 0, 0xFC, 0xBF}, frame)
+	tc, _ = FromTimeCode("00:00:00:00", Smpte25)
+	frame, _ = EncodeLTC(*tc, 0)
+	assert.Equal(t, LTCFrame{0, 0, 0, 0, 0, 0, 0, 0x08, 0xFC, 0xBF}, frame)
+
+	tc, _ = FromTimeCode("23:59:59;29", Smpte2997Drop)
+	frame, err = EncodeLTC(*tc, 0x87654321)
+	assert.Nil(t, err)
+	// frames 9 and user bits 1, tens 2 and drop frame, user bits 2
+	assert.Equal(t, byte(0x19), frame[0])
+	assert.Equal(t, byte(0x26), frame[1])
+	assert.Equal(t, byte(0x39), frame[2])
+	assert.Equal(t, byte(0x45), frame[3]&0xf7)
+	assert.Equal(t, byte(0x59), frame[4])
+	assert.Equal(t, byte(0x65), frame[5])
+	assert.Equal(t, byte(0x73), frame[6])
+	assert.Equal(t, byte(0x82), frame[7])
+	assert.Equal(t, 0, frame.ones()%2)
+
+	tc, _ = FromTimeCode("10:00:00:00", Smpte25)
+	frame, _ = EncodeLTCFlags(*tc, 0, LTCFlags{ColorFrame: true, BinaryGroup: [3]bool{true, false, true}})
+	assert.Equal(t, 1, frame.bits(_ltcColorFrameBit, 1))
+	assert.Equal(t, 1, frame.bits(27, 1))
+	assert.Equal(t, 1, frame.bits(43, 1))
+	assert.Equal(t, 0, frame.bits(58, 1))
+	assert.Equal(t, 0, frame.ones()%2)
+	flags, err := frame.Flags(Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, LTCFlags{ColorFrame: true, BinaryGroup: [3]bool{true, false, true}}, flags)
+	tc, _ = FromTimeCode("10:00:00:00", Smpte30)
+	frame, _ = EncodeLTCFlags(*tc, 0, LTCFlags{BinaryGroup: [3]bool{true, true, true}})
+	assert.Equal(t, 1, frame.bits(43, 1))
+	assert.Equal(t, 1, frame.bits(58, 1))
+	assert.Equal(t, 1, frame.bits(59, 1))
+	flags, _ = frame.Flags(Smpte30)
+	assert.Equal(t, LTCFlags{BinaryGroup: [3]bool{true, true, true}}, flags)
+
+	tc, _ = FromTimeCode("-00:00:01:00", Smpte25)
+	_, err = EncodeLTC(*tc, 0)
+	assert.NotNil(t, err)
+	tc, _ = FromTimeCode("01:00:00:00:00", Smpte25)
+	_, err = EncodeLTC(*tc, 0)
+	assert.NotNil(t, err)
+	tc, _ = FromTimeCode("00:00:00:00", Smpte50)
+	_, err = EncodeLTC(*tc, 0)
+	assert.NotNil(t, err)
+}
+
+func Test_DecodeLTC(t *testing.T) {
+	for _, rate := range []SmpteFrameRate{Smpte24, Smpte2398, Smpte25, Smpte2997Drop, Smpte2997NonDrop, Smpte30} {
+		for _, s := range []string{"00:00:00:00", "00:01:00:02", "09:59:59:23", "23:59:59:23", "12:34:56:07"} {
+			tc, err := FromTimeCode(s, rate)
+			if err != nil {
+				continue
+			}
+			frame, err := EncodeLTC(*tc, 0xDEADBEEF)
+			assert.Nil(t, err, s)
+			assert.Equal(t, 0, frame.ones()%2, s)
+			back, userBits, err := DecodeLTC(frame, rate)
+			assert.Nil(t, err, s)
+			assert.Equal(t, tc.String(), back.String(), s)
+			assert.Equal(t, rate, back.FrameRate(), s)
+			assert.Equal(t, UserBits(0xDEADBEEF), userBits, s)
+		}
+	}
+
+	// the drop frame flag selects the drop frame variant of the rate
+	tc, _ := FromTimeCode("01:00:00;02", Smpte2997Drop)
+	frame, _ := EncodeLTC(*tc, 0)
+	back, _, err := DecodeLTC(frame, Smpte2997NonDrop)
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte2997Drop, back.FrameRate())
+	assert.Equal(t, "01:00:00;02", back.String())
+	_, _, err = DecodeLTC(frame, Smpte25)
+	assert.NotNil(t, err)
+
+	// no sync word
+	frame[9] = 0
+	_, _, err = DecodeLTC(frame, Smpte2997Drop)
+	assert.NotNil(t, err)
+	// frame units above 9
+	tc, _ = FromTimeCode("00:00:00:00", Smpte25)
+	frame, _ = EncodeLTC(*tc, 0)
+	frame[0] = 0x0A
+	_, _, err = DecodeLTC(frame, Smpte25)
+	assert.NotNil(t, err)
+	// frame 25 at 25 fps
+	frame[0], frame[1] = 0x05, 0x02
+	_, _, err = DecodeLTC(frame, Smpte25)
+	assert.NotNil(t, err)
+	// a dropped frame number
+	tc, _ = FromTimeCode("00:01:00;02", Smpte2997Drop)
+	frame, _ = EncodeLTC(*tc, 0)
+	frame[0] = 0
+	_, _, err = DecodeLTC(frame, Smpte2997Drop)
+	assert.NotNil(t, err)
+}
+
+func Test_UserBits(t *testing.T) {
+	u := UserBits(0x87654321)
+	for group := 1; group <= 8; group++ {
+		assert.Equal(t, uint8(group), u.Group(group))
+	}
+}
@@ -0,0 +1,235 @@
+package timecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// _ltcRiseTime is the 10% to 90% rise time of LTC in SMPTE ST 12-1.
+const _ltcRiseTime = 25 * time.Microsecond
+
+// LTCGenerator writes LTC audio: each frame is a LTC frame, see EncodeLTC, biphase mark
+// encoded over the audio samples of the frame in FrameSamples, so NTSC frame rates follow
+// the sample cadence, e.g. 1602, 1601, 1602, 1601, 1602 samples at 29.97 fps and 48 kHz.
+type LTCGenerator struct {
+	// SampleRate is the number of audio samples per second.
+	SampleRate int64
+	// BitDepth is the number of bits per sample, 8, 16, 24 or 32.
+	BitDepth int
+	// Amplitude is the peak level as a fraction of full scale, from 0 to 1.
+	Amplitude float64
+	// RiseTime is the 10% to 90% rise time of each transition, 0 for a square wave.
+	RiseTime time.Duration
+	// UserBits are the user bits of every frame.
+	UserBits UserBits
+	// Flags are the color frame and binary group flags of every frame.
+	Flags LTCFlags
+}
+
+// NewLTCGenerator Initializes a new LTC generator at the sample rate and bit depth, with
+// an amplitude of half of full scale and the ST 12-1 rise time of 25 microseconds.
+func NewLTCGenerator(sampleRate int64, bitDepth int) *LTCGenerator {
+	return &LTCGenerator{
+		SampleRate: sampleRate,
+		BitDepth:   bitDepth,
+		Amplitude:  0.5,
+		RiseTime:   _ltcRiseTime,
+	}
+}
+
+// validate Checks the settings of the generator.
+func (g *LTCGenerator) validate() error {
+	if g.SampleRate <= 0 {
+		return fmt.Errorf("timecode: invalid sample rate: '%v'", g.SampleRate)
+	}
+	if g.BitDepth != 8 && g.BitDepth != 16 && g.BitDepth != 24 && g.BitDepth != 32 {
+		return fmt.Errorf("timecode: invalid bit depth: '%v'", g.BitDepth)
+	}
+	if g.Amplitude <= 0 || g.Amplitude > 1 {
+		return fmt.Errorf("timecode: invalid amplitude: '%v'", g.Amplitude)
+	}
+	if g.RiseTime < 0 {
+		return fmt.Errorf("timecode: invalid rise time: '%v'", g.RiseTime)
+	}
+	return nil
+}
+
+// Samples Returns the LTC audio of a number of frames from the start, as samples from -1
+// to 1 of full scale. The labels roll over at midnight.
+func (g *LTCGenerator) Samples(start TimeCode, frames int64) ([]float64, error) {
+	var ret []float64
+	err := g.generate(start, frames, func(samples []float64) error {
+		ret = append(ret, samples...)
+		return nil
+	})
+	return ret, err
+}
+
+// WritePCM Writes the LTC audio of a number of frames from the start to the writer, as
+// mono little endian PCM at the bit depth. 8 bit samples are unsigned, like WAV files.
+func (g *LTCGenerator) WritePCM(w io.Writer, start TimeCode, frames int64) error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+	return g.generate(start, frames, func(samples []float64) error {
+		_, err := w.Write(g.pcm(samples))
+		return err
+	})
+}
+
+// WriteWAV Writes the LTC audio of a number of frames from the start to the writer, as a
+// mono PCM WAV file at the sample rate and bit depth. A data chunk of an odd number of
+// bytes is followed by the RIFF pad byte.
+func (g *LTCGenerator) WriteWAV(w io.Writer, start TimeCode, frames int64) error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+	first, last, err := g.sampleRange(start, frames)
+	if err != nil {
+		return err
+	}
+	blockAlign := int64(g.BitDepth / 8)
+	dataSize := (last - first) * blockAlign
+	pad := dataSize % 2
+	if dataSize+pad+36 > math.MaxUint32 {
+		return errors.New("timecode: LTC audio is too long for a WAV file")
+	}
+	header := []interface{}{
+		[4]byte{'R', 'I', 'F', 'F'}, uint32(dataSize + pad + 36), [4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '}, uint32(16),
+		uint16(1), uint16(1), uint32(g.SampleRate), uint32(g.SampleRate * blockAlign),
+		uint16(blockAlign), uint16(g.BitDepth),
+		[4]byte{'d', 'a', 't', 'a'}, uint32(dataSize),
+	}
+	for _, field := range header {
+		if err := binary.Write(w, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+	if err := g.WritePCM(w, start, frames); err != nil {
+		return err
+	}
+	if pad != 0 {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	return nil
+}
+
+// sampleRange Returns the first sample of the start frame and the first sample after
+// the last frame.
+func (g *LTCGenerator) sampleRange(start TimeCode, frames int64) (first, last int64, err error) {
+	if err := validateSampleRate(g.SampleRate, start.FrameRate()); err != nil {
+		return 0, 0, err
+	}
+	if frames < 0 {
+		return 0, 0, fmt.Errorf("timecode: invalid number of frames: '%v'", frames)
+	}
+	if start.Negative() {
+		return 0, 0, errors.New(_smpte12MMinValueOverflow)
+	}
+	first = absoluteTimeToSamples(framesToAbsoluteTime(start.TotalFrames(), start.FrameRate()), g.SampleRate)
+	last = absoluteTimeToSamples(framesToAbsoluteTime(start.TotalFrames()+frames, start.FrameRate()), g.SampleRate)
+	return first, last, nil
+}
+
+// generate Renders the LTC audio of a number of frames from the start, a frame at a time.
+func (g *LTCGenerator) generate(start TimeCode, frames int64, write func([]float64) error) error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+	if _, err := ltcRateLayout(start.FrameRate()); err != nil {
+		return err
+	}
+	if _, _, err := g.sampleRange(start, frames); err != nil {
+		return err
+	}
+	rate := start.FrameRate()
+	day := rateRecord(rate).hours * 24
+	// the box filter width of a linear ramp with the rise time from 10% to 90%
+	ramp := g.RiseTime.Seconds() / 0.8 * float64(g.SampleRate)
+	for frame := start.TotalFrames(); frame < start.TotalFrames()+frames; frame++ {
+		label, err := fromAbsoluteTime(framesToAbsoluteTime(frame%day, rate), rate)
+		if err != nil {
+			return err
+		}
+		codeword, err := EncodeLTCFlags(*label, g.UserBits, g.Flags)
+		if err != nil {
+			return err
+		}
+		previous, _ := FrameSamples(frame-1, g.SampleRate, rate)
+		length, _ := FrameSamples(frame, g.SampleRate, rate)
+		if ramp >= float64(length)/160 || ramp >= float64(previous)/160 {
+			return fmt.Errorf("timecode: rise time is longer than half a LTC bit: '%v'", g.RiseTime)
+		}
+		if err := write(g.render(codeword, length, previous, ramp)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render Returns the biphase mark samples of a LTC frame of length samples, after a frame
+// of previous samples. Every bit has a transition at its start and a 1 has another in its
+// middle. The polarity correction bit makes the number of transitions in a frame even, so
+// every frame starts low and rises at its first sample.
+func (g *LTCGenerator) render(codeword LTCFrame, length, previous int64, ramp float64) []float64 {
+	bit := float64(length) / 80
+	// the last transition of the previous frame, in the middle of the 1 ending the sync word
+	edges := []float64{-float64(previous) / 160}
+	for i := uint(0); i < 80; i++ {
+		edges = append(edges, float64(i)*bit)
+		if codeword.bits(i, 1) == 1 {
+			edges = append(edges, (float64(i)+0.5)*bit)
+		}
+	}
+	edges = append(edges, float64(length))
+	// levels[k] is the level after edges[k], the level before the first edge is high
+	level := func(k int) float64 {
+		if k%2 == 0 {
+			return -g.Amplitude
+		}
+		return g.Amplitude
+	}
+	ret := make([]float64, length)
+	k := 0
+	for n := range ret {
+		t := float64(n)
+		for k+1 < len(edges) && edges[k+1] <= t+ramp/2 {
+			k++
+		}
+		// at most one edge is within the ramp of a sample, the latest one before its end
+		if ramp > 0 && edges[k] > t-ramp/2 {
+			after := (t + ramp/2 - edges[k]) / ramp
+			ret[n] = level(k)*after + level(k-1)*(1-after)
+		} else {
+			ret[n] = level(k)
+		}
+	}
+	return ret
+}
+
+// pcm Returns the samples as little endian PCM at the bit depth.
+func (g *LTCGenerator) pcm(samples []float64) []byte {
+	size := g.BitDepth / 8
+	ret := make([]byte, len(samples)*size)
+	for i, sample := range samples {
+		b := ret[i*size:]
+		switch g.BitDepth {
+		case 8:
+			b[0] = byte(128 + int(math.Round(sample*127)))
+		case 16:
+			binary.LittleEndian.PutUint16(b, uint16(int16(math.Round(sample*math.MaxInt16))))
+		case 24:
+			v := uint32(int32(math.Round(sample * (1<<23 - 1))))
+			b[0], b[1], b[2] = byte(v), byte(v>>8), byte(v>>16)
+		case 32:
+			binary.LittleEndian.PutUint32(b, uint32(int32(math.Round(sample*math.MaxInt32))))
+		}
+	}
+	return ret
+}
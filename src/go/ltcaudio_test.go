@@ -0,0 +1,137 @@
+package timecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readLTCSamples Reads the biphase mark bits of a frame of samples in the middle of each half bit.
+func readLTCSamples(samples []float64) LTCFrame {
+	var ret LTCFrame
+	bit := float64(len(samples)) / 80
+	for i := uint(0); i < 80; i++ {
+		first := samples[int((float64(i)+0.25)*bit)]
+		second := samples[int((float64(i)+0.75)*bit)]
+		if (first > 0) != (second > 0) {
+			ret.setBits(i, 1, 1)
+		}
+	}
+	return ret
+}
+
+func Test_LTCGenerator_Samples(t *testing.T) {
+	g := NewLTCGenerator(48000, 16)
+	start, _ := FromTimeCode("00:59:59;28", Smpte2997Drop)
+	samples, err := g.Samples(*start, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 1602+1601+1602+1601+1602, len(samples))
+	// every frame starts low and rises at its first sample
+	offset := 0
+	for i, label := range []string{"00:59:59;28", "00:59:59;29", "01:00:00;00", "01:00:00;01", "01:00:00;02"} {
+		length, _ := FrameSamples(start.TotalFrames()+int64(i), 48000, Smpte2997Drop)
+		frame := samples[offset : offset+int(length)]
+		assert.Equal(t, 0.0, frame[0], label)
+		assert.Equal(t, 0.5, frame[2], label)
+		tc, _, err := DecodeLTC(readLTCSamples(frame), Smpte2997Drop)
+		assert.Nil(t, err, label)
+		assert.Equal(t, label, tc.String())
+		offset += int(length)
+	}
+	for _, sample := range samples {
+		assert.True(t, math.Abs(sample) <= 0.5)
+	}
+
+	// a square wave, and the labels roll over at midnight
+	g = &LTCGenerator{SampleRate: 44100, BitDepth: 16, Amplitude: 1, UserBits: 0x12345678}
+	start, _ = FromTimeCode("23:59:59:24", Smpte25)
+	samples, _ = g.Samples(*start, 2)
+	assert.Equal(t, 2*1764, len(samples))
+	for _, sample := range samples {
+		assert.Equal(t, 1.0, math.Abs(sample))
+	}
+	tc, userBits, err := DecodeLTC(readLTCSamples(samples[1764:]), Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00", tc.String())
+	assert.Equal(t, UserBits(0x12345678), userBits)
+
+	// a linear transition from -1 to 1 over 4 samples, 3.2 samples or 25 microseconds from 10% to 90% at 128 kHz
+	g = &LTCGenerator{SampleRate: 128000, BitDepth: 16, Amplitude: 1, RiseTime: 25 * time.Microsecond}
+	start, _ = FromTimeCode("00:00:00:00", Smpte25)
+	samples, _ = g.Samples(*start, 1)
+	assert.Equal(t, []float64{0, 0.5, 1}, samples[:3])
+
+	// errors
+	_, err = NewLTCGenerator(48000, 16).Samples(TimeCode{frameRate: Smpte50}, 1)
+	assert.NotNil(t, err)
+	_, err = NewLTCGenerator(0, 16).Samples(*start, 1)
+	assert.NotNil(t, err)
+	_, err = NewLTCGenerator(48000, 12).Samples(*start, 1)
+	assert.NotNil(t, err)
+	_, err = NewLTCGenerator(48000, 16).Samples(*start, -1)
+	assert.NotNil(t, err)
+	_, err = (&LTCGenerator{SampleRate: 48000, BitDepth: 16, Amplitude: 1.5}).Samples(*start, 1)
+	assert.NotNil(t, err)
+	_, err = (&LTCGenerator{SampleRate: 8000, BitDepth: 16, Amplitude: 1, RiseTime: time.Millisecond}).Samples(*start, 1)
+	assert.NotNil(t, err)
+	negative, _ := FromTimeCode("-00:00:01:00", Smpte25)
+	_, err = NewLTCGenerator(48000, 16).Samples(*negative, 1)
+	assert.NotNil(t, err)
+}
+
+func Test_LTCGenerator_WritePCM(t *testing.T) {
+	start, _ := FromTimeCode("10:00:00:00", Smpte24)
+	for _, depth := range []int{8, 16, 24, 32} {
+		g := &LTCGenerator{SampleRate: 48000, BitDepth: depth, Amplitude: 1}
+		var buf bytes.Buffer
+		assert.Nil(t, g.WritePCM(&buf, *start, 3))
+		assert.Equal(t, 3*2000*depth/8, buf.Len())
+		// the first sample rises to full scale
+		b := buf.Bytes()
+		switch depth {
+		case 8:
+			assert.Equal(t, byte(255), b[0])
+		case 16:
+			assert.Equal(t, uint16(math.MaxInt16), binary.LittleEndian.Uint16(b))
+		case 24:
+			assert.Equal(t, []byte{0xff, 0xff, 0x7f}, b[:3])
+		case 32:
+			assert.Equal(t, uint32(math.MaxInt32), binary.LittleEndian.Uint32(b))
+		}
+	}
+}
+
+func Test_LTCGenerator_WriteWAV(t *testing.T) {
+	start, _ := FromTimeCode("01:00:00:00", Smpte30)
+	var buf bytes.Buffer
+	assert.Nil(t, NewLTCGenerator(48000, 16).WriteWAV(&buf, *start, 30))
+	b := buf.Bytes()
+	assert.Equal(t, 44+48000*2, len(b))
+	assert.Equal(t, "RIFF", string(b[0:4]))
+	assert.Equal(t, uint32(36+48000*2), binary.LittleEndian.Uint32(b[4:]))
+	assert.Equal(t, "WAVEfmt ", string(b[8:16]))
+	assert.Equal(t, uint16(1), binary.LittleEndian.Uint16(b[20:]))
+	assert.Equal(t, uint16(1), binary.LittleEndian.Uint16(b[22:]))
+	assert.Equal(t, uint32(48000), binary.LittleEndian.Uint32(b[24:]))
+	assert.Equal(t, uint32(96000), binary.LittleEndian.Uint32(b[28:]))
+	assert.Equal(t, uint16(2), binary.LittleEndian.Uint16(b[32:]))
+	assert.Equal(t, uint16(16), binary.LittleEndian.Uint16(b[34:]))
+	assert.Equal(t, "data", string(b[36:40]))
+	assert.Equal(t, uint32(48000*2), binary.LittleEndian.Uint32(b[40:]))
+
+	// an odd number of 8 bit samples is followed by a pad byte
+	start, _ = FromFrames(0, Smpte2997NonDrop)
+	buf.Reset()
+	assert.Nil(t, NewLTCGenerator(44100, 8).WriteWAV(&buf, *start, 1))
+	b = buf.Bytes()
+	samples, _ := FrameSamples(0, 44100, Smpte2997NonDrop)
+	assert.Equal(t, int64(1471), samples)
+	assert.Equal(t, 44+int(samples)+1, len(b))
+	assert.Equal(t, uint32(36+samples+1), binary.LittleEndian.Uint32(b[4:]))
+	assert.Equal(t, uint32(samples), binary.LittleEndian.Uint32(b[40:]))
+	assert.Equal(t, byte(0), b[len(b)-1])
+}
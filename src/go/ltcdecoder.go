@@ -0,0 +1,272 @@
+package timecode
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// _ltcMinLevel is the lowest peak level of LTC audio, as a fraction of full scale,
+	// below which the decoder takes the signal as a dropout.
+	_ltcMinLevel = 0.01
+	// _ltcMaxVarispeed is how far the bit period may drift from the nominal bit period.
+	_ltcMaxVarispeed = 0.2
+)
+
+// DecodedLTC is a LTC frame read from audio by a LTCDecoder.
+type DecodedLTC struct {
+	// TimeCode is the timecode of the frame, nil when Err is set.
+	TimeCode *TimeCode
+	// UserBits are the user bits of the frame.
+	UserBits UserBits
+	// Frame is the LTC frame as read, in the order of SMPTE ST 12-1 even in reverse.
+	Frame LTCFrame
+	// Start and End are the sample positions of the first transition of the frame in the
+	// audio, and of the transition that ends it, counted from the first sample decoded.
+	Start, End int64
+	// Reverse indicates the frame was played backwards.
+	Reverse bool
+	// Confidence is from 0 to 1, how close the transitions of the frame were to the bit
+	// period, 0 being a quarter of a bit away on average.
+	Confidence float64
+	// Err is the error decoding the frame, such as a BCD digit above 9.
+	Err error
+}
+
+// LTCDecoder reads LTC frames from audio samples at any sample rate. It reads the
+// biphase mark transitions rather than levels, so it reads inverted audio, reads frames
+// played backwards, follows varispeed of up to 10% and resynchronizes after dropouts.
+// A frame ends at the first transition of the next frame, so it is returned once that
+// transition is decoded.
+type LTCDecoder struct {
+	rate SmpteFrameRate
+	// nominal is the bit period at the frame rate, period the bit period followed in samples
+	nominal, period float64
+
+	position int64   // position of the next sample
+	previous float64 // the previous sample
+	level    int     // the level of the signal, 1 or -1, 0 before the first transition
+	envelope float64 // the peak level of the signal
+	crossing float64 // position of the latest zero crossing
+	edge     float64 // position of the latest transition, -1 before the first one
+	half     float64 // length of the first half of a 1 bit, 0 when none is pending
+	halfEdge float64 // position of the start of the pending 1 bit
+
+	bits       [80]uint8
+	starts     [80]float64
+	deviations [80]float64
+	count      int
+	synced     bool
+
+	bitErrors, dropouts int64
+}
+
+// NewLTCDecoder Initializes a new LTC decoder of audio at the sample rate, for LTC at the
+// frame rate, which a LTC frame cannot tell apart between 24, 25 and 30 fps.
+func NewLTCDecoder(sampleRate int64, rate SmpteFrameRate) (*LTCDecoder, error) {
+	if err := validateSampleRate(sampleRate, rate); err != nil {
+		return nil, err
+	}
+	if _, err := ltcRateLayout(rate); err != nil {
+		return nil, err
+	}
+	nominal := ratFloat64(newRat(sampleRate*rateRecord(rate).den, rateRecord(rate).num*80))
+	if nominal < 4 {
+		return nil, fmt.Errorf("timecode: sample rate is too low for LTC: '%v'", sampleRate)
+	}
+	return &LTCDecoder{rate: rate, nominal: nominal, period: nominal, edge: -1}, nil
+}
+
+// BitErrors Returns the number of times the decoder lost bit synchronization.
+func (d *LTCDecoder) BitErrors() int64 {
+	return d.bitErrors
+}
+
+// Dropouts Returns the number of times the signal stopped while synchronized.
+func (d *LTCDecoder) Dropouts() int64 {
+	return d.dropouts
+}
+
+// Decode Decodes samples from -1 to 1 of full scale, and returns the frames that end in them.
+func (d *LTCDecoder) Decode(samples []float64) []DecodedLTC {
+	var ret []DecodedLTC
+	for _, sample := range samples {
+		if frame, ok := d.sample(sample); ok {
+			ret = append(ret, frame)
+		}
+	}
+	return ret
+}
+
+// DecodeInt16 Decodes 16 bit samples, and returns the frames that end in them.
+func (d *LTCDecoder) DecodeInt16(samples []int16) []DecodedLTC {
+	var ret []DecodedLTC
+	for _, sample := range samples {
+		if frame, ok := d.sample(float64(sample) / -math.MinInt16); ok {
+			ret = append(ret, frame)
+		}
+	}
+	return ret
+}
+
+// DecodeFloat32 Decodes samples from -1 to 1 of full scale, and returns the frames that end in them.
+func (d *LTCDecoder) DecodeFloat32(samples []float32) []DecodedLTC {
+	var ret []DecodedLTC
+	for _, sample := range samples {
+		if frame, ok := d.sample(float64(sample)); ok {
+			ret = append(ret, frame)
+		}
+	}
+	return ret
+}
+
+// sample Decodes the next sample, and returns the frame it ends if any.
+func (d *LTCDecoder) sample(sample float64) (DecodedLTC, bool) {
+	position := float64(d.position)
+	d.position++
+	previous := d.previous
+	d.previous = sample
+
+	// the envelope falls to a third over a nominal frame
+	d.envelope *= 1 - 1/(d.nominal*80)
+	if math.Abs(sample) > d.envelope {
+		d.envelope = math.Abs(sample)
+	}
+	if (previous < 0) != (sample < 0) {
+		d.crossing = position - 1 + previous/(previous-sample)
+	}
+	if d.envelope < _ltcMinLevel || (d.edge >= 0 && position-d.edge > 2*d.period) {
+		d.dropout()
+		if d.envelope < _ltcMinLevel {
+			d.level = 0
+		}
+		return DecodedLTC{}, false
+	}
+
+	// a transition once the signal passes a quarter of the peak level the other way
+	threshold := d.envelope / 4
+	switch {
+	case d.level <= 0 && sample > threshold:
+	case d.level >= 0 && sample < -threshold:
+	default:
+		return DecodedLTC{}, false
+	}
+	first := d.level == 0
+	d.level = 1
+	if sample < 0 {
+		d.level = -1
+	}
+	if first {
+		return DecodedLTC{}, false
+	}
+	edge := d.crossing
+	if edge <= d.edge {
+		edge = position
+	}
+	previousEdge := d.edge
+	d.edge = edge
+	if previousEdge < 0 {
+		return DecodedLTC{}, false
+	}
+	return d.interval(previousEdge, edge-previousEdge)
+}
+
+// interval Reads the bits of the time between two transitions, from the start position.
+func (d *LTCDecoder) interval(start, interval float64) (DecodedLTC, bool) {
+	switch {
+	case interval > 0.75*d.period && interval < 1.5*d.period:
+		if d.half > 0 {
+			d.bitError()
+			return DecodedLTC{}, false
+		}
+		d.adapt(interval)
+		return d.bit(0, start, math.Abs(interval-d.period)/d.period)
+	case interval > 0.25*d.period && interval <= 0.75*d.period:
+		if d.half == 0 {
+			d.half, d.halfEdge = interval, start
+			return DecodedLTC{}, false
+		}
+		half := d.half
+		d.half = 0
+		d.adapt(half + interval)
+		return d.bit(1, d.halfEdge, (math.Abs(half-d.period/2)+math.Abs(interval-d.period/2))/d.period)
+	}
+	d.bitError()
+	return DecodedLTC{}, false
+}
+
+// adapt Follows the bit period towards the length of a bit, within the varispeed range.
+func (d *LTCDecoder) adapt(bit float64) {
+	d.period += (bit - d.period) / 8
+	d.period = math.Max(d.period, d.nominal*(1-_ltcMaxVarispeed))
+	d.period = math.Min(d.period, d.nominal*(1+_ltcMaxVarispeed))
+}
+
+// bitError Drops the bits read since a frame, after transitions out of the bit period.
+func (d *LTCDecoder) bitError() {
+	if d.synced {
+		d.bitErrors++
+	}
+	d.reset()
+}
+
+// dropout Drops the bits read since a frame, after the signal stopped.
+func (d *LTCDecoder) dropout() {
+	if d.synced {
+		d.dropouts++
+	}
+	d.reset()
+	d.edge = -1
+	d.period = d.nominal
+}
+
+// reset Drops the bits read since a frame.
+func (d *LTCDecoder) reset() {
+	d.count, d.half, d.synced = 0, 0, false
+}
+
+// bit Adds a bit that starts at the position, and returns the frame it ends if any.
+func (d *LTCDecoder) bit(bit uint8, start, deviation float64) (DecodedLTC, bool) {
+	if d.count == len(d.bits) {
+		copy(d.bits[:], d.bits[1:])
+		copy(d.starts[:], d.starts[1:])
+		copy(d.deviations[:], d.deviations[1:])
+		d.count--
+	}
+	d.bits[d.count], d.starts[d.count], d.deviations[d.count] = bit, start, deviation
+	d.count++
+	if d.count < len(d.bits) {
+		return DecodedLTC{}, false
+	}
+
+	var frame LTCFrame
+	reverse := false
+	for i := range d.bits {
+		frame.setBits(uint(i), 1, int(d.bits[i]))
+	}
+	if frame.bits(64, 16) != _ltcSyncWord {
+		// played backwards the frame starts with its sync word from bit 79
+		for i := range d.bits {
+			frame.setBits(uint(79-i), 1, int(d.bits[i]))
+		}
+		if frame.bits(64, 16) != _ltcSyncWord {
+			return DecodedLTC{}, false
+		}
+		reverse = true
+	}
+
+	ret := DecodedLTC{
+		Frame:   frame,
+		Start:   int64(math.Round(d.starts[0])),
+		End:     int64(math.Round(d.edge)),
+		Reverse: reverse,
+	}
+	total := 0.0
+	for _, dev := range d.deviations {
+		total += dev
+	}
+	ret.Confidence = math.Max(0, 1-4*total/float64(len(d.deviations)))
+	ret.TimeCode, ret.UserBits, ret.Err = DecodeLTC(frame, d.rate)
+	d.count, d.synced = 0, true
+	return ret, true
+}
@@ -0,0 +1,181 @@
+package timecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ltcAudio Returns the LTC audio of a number of frames from the start.
+func ltcAudio(t *testing.T, start string, frames int64, sampleRate int64, rate SmpteFrameRate) []float64 {
+	tc, err := FromTimeCode(start, rate)
+	assert.Nil(t, err)
+	g := NewLTCGenerator(sampleRate, 16)
+	g.UserBits = 0x0BADCAFE
+	samples, err := g.Samples(*tc, frames)
+	assert.Nil(t, err)
+	return samples
+}
+
+// resample Returns the samples played at the speed, by linear interpolation.
+func resample(samples []float64, speed float64) []float64 {
+	var ret []float64
+	for pos := 0.0; pos < float64(len(samples)-1); pos += speed {
+		i := int(pos)
+		ret = append(ret, samples[i]+(samples[i+1]-samples[i])*(pos-float64(i)))
+	}
+	return ret
+}
+
+// ltcLabels Returns the labels of the decoded frames, and checks they have no errors.
+func ltcLabels(t *testing.T, frames []DecodedLTC) []string {
+	var ret []string
+	for _, frame := range frames {
+		assert.Nil(t, frame.Err)
+		if frame.Err == nil {
+			ret = append(ret, frame.TimeCode.String())
+			assert.Equal(t, UserBits(0x0BADCAFE), frame.UserBits)
+		}
+	}
+	return ret
+}
+
+func Test_LTCDecoder(t *testing.T) {
+	samples := ltcAudio(t, "00:59:59;28", 5, 48000, Smpte2997Drop)
+	d, err := NewLTCDecoder(48000, Smpte2997Drop)
+	assert.Nil(t, err)
+	frames := d.Decode(samples)
+	// the first transition of the audio is not read, so the first frame is lost, and the
+	// last frame ends at the first transition of the next frame, so it is not read either
+	assert.Equal(t, []string{"00:59:59;29", "01:00:00;00", "01:00:00;01"}, ltcLabels(t, frames))
+	assert.Equal(t, int64(1602), frames[0].Start)
+	assert.Equal(t, int64(1602+1601), frames[0].End)
+	assert.Equal(t, int64(1602+1601), frames[1].Start)
+	for _, frame := range frames {
+		assert.False(t, frame.Reverse)
+		assert.True(t, frame.Confidence > 0.9)
+	}
+
+	// other sample rates and frame rates
+	for _, rate := range []SmpteFrameRate{Smpte24, Smpte25, Smpte30} {
+		for _, sampleRate := range []int64{44100, 96000} {
+			samples = ltcAudio(t, "10:00:00:00", 4, sampleRate, rate)
+			d, _ = NewLTCDecoder(sampleRate, rate)
+			assert.Equal(t, []string{"10:00:00:01", "10:00:00:02"}, ltcLabels(t, d.Decode(samples)), rate.String())
+		}
+	}
+}
+
+func Test_LTCDecoder_Polarity(t *testing.T) {
+	samples := ltcAudio(t, "01:00:00:00", 4, 48000, Smpte25)
+	for i := range samples {
+		samples[i] = -samples[i]
+	}
+	d, _ := NewLTCDecoder(48000, Smpte25)
+	assert.Equal(t, []string{"01:00:00:01", "01:00:00:02"}, ltcLabels(t, d.Decode(samples)))
+}
+
+func Test_LTCDecoder_Reverse(t *testing.T) {
+	samples := ltcAudio(t, "01:00:00:00", 4, 48000, Smpte25)
+	for i, j := 0, len(samples)-1; i < j; i, j = i+1, j-1 {
+		samples[i], samples[j] = samples[j], samples[i]
+	}
+	d, _ := NewLTCDecoder(48000, Smpte25)
+	frames := d.Decode(samples)
+	assert.Equal(t, []string{"01:00:00:02", "01:00:00:01"}, ltcLabels(t, frames))
+	for _, frame := range frames {
+		assert.True(t, frame.Reverse)
+	}
+	// sample N of the reversed audio is sample 7679-N of the audio
+	assert.Equal(t, int64(1919), frames[0].Start)
+	assert.Equal(t, int64(3839), frames[0].End)
+}
+
+func Test_LTCDecoder_Varispeed(t *testing.T) {
+	samples := ltcAudio(t, "01:00:00;00", 8, 48000, Smpte2997Drop)
+	for _, speed := range []float64{0.9, 0.95, 1.05, 1.1} {
+		d, _ := NewLTCDecoder(48000, Smpte2997Drop)
+		labels := ltcLabels(t, d.Decode(resample(samples, speed)))
+		assert.Equal(t, []string{"01:00:00;01", "01:00:00;02", "01:00:00;03", "01:00:00;04", "01:00:00;05", "01:00:00;06"}, labels, "%v", speed)
+	}
+}
+
+func Test_LTCDecoder_Dropout(t *testing.T) {
+	samples := ltcAudio(t, "01:00:00:00", 8, 48000, Smpte24)
+	// silence from the middle of frame 3 to the middle of frame 4
+	for i := 3*2000 + 1000; i < 4*2000+1000; i++ {
+		samples[i] = 0
+	}
+	d, _ := NewLTCDecoder(48000, Smpte24)
+	labels := ltcLabels(t, d.Decode(samples))
+	assert.Equal(t, []string{"01:00:00:01", "01:00:00:02", "01:00:00:05", "01:00:00:06"}, labels)
+	assert.Equal(t, int64(1), d.Dropouts())
+
+	// a burst of noise at full scale, across frame 3
+	samples = ltcAudio(t, "01:00:00:00", 8, 48000, Smpte24)
+	for i := 3*2000 + 500; i < 3*2000+600; i += 2 {
+		samples[i], samples[i+1] = 1, -1
+	}
+	d, _ = NewLTCDecoder(48000, Smpte24)
+	labels = ltcLabels(t, d.Decode(samples))
+	assert.Equal(t, []string{"01:00:00:01", "01:00:00:02", "01:00:00:04", "01:00:00:05", "01:00:00:06"}, labels)
+	assert.True(t, d.BitErrors() > 0)
+}
+
+func Test_LTCDecoder_PCM(t *testing.T) {
+	start, _ := FromTimeCode("23:59:59:28", Smpte30)
+	g := NewLTCGenerator(44100, 16)
+	var buf bytes.Buffer
+	assert.Nil(t, g.WritePCM(&buf, *start, 5))
+	pcm := make([]int16, buf.Len()/2)
+	assert.Nil(t, binary.Read(&buf, binary.LittleEndian, pcm))
+	d, _ := NewLTCDecoder(44100, Smpte30)
+	var labels []string
+	// in blocks that split frames
+	for i := 0; i < len(pcm); i += 1000 {
+		end := i + 1000
+		if end > len(pcm) {
+			end = len(pcm)
+		}
+		for _, frame := range d.DecodeInt16(pcm[i:end]) {
+			labels = append(labels, frame.TimeCode.String())
+		}
+	}
+	assert.Equal(t, []string{"23:59:59:29", "00:00:00:00", "00:00:00:01"}, labels)
+
+	samples := ltcAudio(t, "01:00:00:00", 4, 48000, Smpte25)
+	floats := make([]float32, len(samples))
+	for i, sample := range samples {
+		floats[i] = float32(sample)
+	}
+	d, _ = NewLTCDecoder(48000, Smpte25)
+	assert.Equal(t, []string{"01:00:00:01", "01:00:00:02"}, ltcLabels(t, d.DecodeFloat32(floats)))
+}
+
+func Test_LTCDecoder_Errors(t *testing.T) {
+	_, err := NewLTCDecoder(0, Smpte25)
+	assert.NotNil(t, err)
+	_, err = NewLTCDecoder(48000, Smpte50)
+	assert.NotNil(t, err)
+	_, err = NewLTCDecoder(4000, Smpte30)
+	assert.NotNil(t, err)
+
+	// a frame with a bad BCD digit is read with an error
+	var frame LTCFrame
+	frame.setBits(0, 4, 0xA)
+	frame.setBits(64, 16, _ltcSyncWord)
+	frame.setBits(_ltcLayout25.polarity, 1, frame.ones()%2)
+	g := NewLTCGenerator(48000, 16)
+	var samples []float64
+	for i := 0; i < 3; i++ {
+		samples = append(samples, g.render(frame, 1920, 1920, 0)...)
+	}
+	d, _ := NewLTCDecoder(48000, Smpte25)
+	frames := d.Decode(samples)
+	assert.Equal(t, 1, len(frames))
+	assert.NotNil(t, frames[0].Err)
+	assert.Nil(t, frames[0].TimeCode)
+	assert.Equal(t, frame, frames[0].Frame)
+}
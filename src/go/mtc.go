@@ -0,0 +1,223 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// _mtcQuarterFrame is the status byte of a MIDI time code quarter frame message.
+	_mtcQuarterFrame = 0xF1
+	// _sysExStart and _sysExEnd start and end a MIDI system exclusive message.
+	_sysExStart = 0xF0
+	_sysExEnd   = 0xF7
+	// _mtcAllDevices is the device ID of a full frame message to every device.
+	_mtcAllDevices = 0x7F
+)
+
+// _mtcRates is the frame rate of each 2 bit MIDI time code rate code.
+var _mtcRates = [4]SmpteFrameRate{Smpte24, Smpte25, Smpte2997Drop, Smpte30}
+
+// mtcRateCode Returns the 2 bit MIDI time code rate code of the frame rate: 0 for 24 fps,
+// 1 for 25 fps, 2 for 29.97 DF and 3 for 30 fps. MIDI time code has no NTSC rates without
+// drop frame, so 23.98 fps is sent as 24 fps, and 29.97 NDF as 30 fps, with the same labels.
+func mtcRateCode(rate SmpteFrameRate) (int64, error) {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return 0, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	switch {
+	case rec.frames == 24 && rec.drop == 0:
+		return 0, nil
+	case rec.frames == 25 && rec.drop == 0:
+		return 1, nil
+	case rec.frames == 30 && rec.drop == 2:
+		return 2, nil
+	case rec.frames == 30 && rec.drop == 0:
+		return 3, nil
+	}
+	return 0, fmt.Errorf("timecode: frame rate is not carried by MIDI time code: '%v'", rate)
+}
+
+// mtcSegments Returns the segments of the timecode and the rate code, for a timecode from
+// 00:00:00:00 to 23:59:59:29.
+func mtcSegments(tc TimeCode) (code, hours, minutes, seconds, frames int64, err error) {
+	if code, err = mtcRateCode(tc.FrameRate()); err != nil {
+		return
+	}
+	var days int64
+	days, hours, minutes, seconds, frames = tc.segments()
+	if tc.Negative() {
+		err = errors.New(_smpte12MMinValueOverflow)
+	} else if days != 0 {
+		err = fmt.Errorf(_smpte12MMaxValueOverflow, tc.TotalSecondsPrecision(), ratFloat64(maxValue(tc.FrameRate())))
+	}
+	return
+}
+
+// mtcTimeCode Returns the timecode of the segments at the rate code.
+func mtcTimeCode(code, hours, minutes, seconds, frames int64) (*TimeCode, error) {
+	rate := _mtcRates[code&3]
+	if err := validateSegments(0, hours, minutes, seconds, frames, rate); err != nil {
+		return nil, err
+	}
+	if frames < droppedFrames(minutes, seconds, rate) {
+		label := fmt.Sprintf("%02d:%02d:%02d;%02d", hours, minutes, seconds, frames)
+		return nil, fmt.Errorf(_smpte12MDroppedFrame, label, rate)
+	}
+	return fromAbsoluteTime(framesToAbsoluteTime(segmentsToFrames(0, hours, minutes, seconds, frames, rate), rate), rate)
+}
+
+// EncodeMTCQuarterFrames Encodes the timecode as the 8 MIDI time code quarter frame
+// messages, 0xF1 then the piece number in bits 4 to 6 and 4 bits of the timecode: the low
+// and high nibbles of the frames, seconds, minutes and hours, with the rate code in bits
+// 1 and 2 of the last piece. The messages are sent over 2 frames from the timecode, a
+// quarter of a frame apart, in reverse order when playing backwards.
+func EncodeMTCQuarterFrames(tc TimeCode) ([8][2]byte, error) {
+	var ret [8][2]byte
+	code, hours, minutes, seconds, frames, err := mtcSegments(tc)
+	if err != nil {
+		return ret, err
+	}
+	values := [4]int64{frames, seconds, minutes, hours | code<<5}
+	for piece := range ret {
+		value := values[piece/2] & 0xF
+		if piece%2 == 1 {
+			value = values[piece/2] >> 4
+		}
+		ret[piece] = [2]byte{_mtcQuarterFrame, byte(piece<<4) | byte(value)}
+	}
+	return ret, nil
+}
+
+// EncodeMTCFullFrame Encodes the timecode as a MIDI time code full frame message to every
+// device, F0 7F 7F 01 01 hr mn sc fr F7, with the rate code in bits 5 and 6 of hr. A full
+// frame message locates a receiver without the latency of the quarter frame messages.
+func EncodeMTCFullFrame(tc TimeCode) ([10]byte, error) {
+	code, hours, minutes, seconds, frames, err := mtcSegments(tc)
+	if err != nil {
+		return [10]byte{}, err
+	}
+	return [10]byte{_sysExStart, 0x7F, _mtcAllDevices, 0x01, 0x01,
+		byte(code<<5 | hours), byte(minutes), byte(seconds), byte(frames), _sysExEnd}, nil
+}
+
+// DecodeMTCFullFrame Decodes a MIDI time code full frame message to any device.
+func DecodeMTCFullFrame(message []byte) (*TimeCode, error) {
+	if len(message) != 10 || message[0] != _sysExStart || message[1] != 0x7F ||
+		message[3] != 0x01 || message[4] != 0x01 || message[9] != _sysExEnd {
+		return nil, errors.New("timecode: not a MIDI time code full frame message")
+	}
+	for _, b := range message[2:9] {
+		if b > 0x7F {
+			return nil, errors.New("timecode: not a MIDI time code full frame message")
+		}
+	}
+	return mtcTimeCode(int64(message[5]>>5), int64(message[5]&0x1F), int64(message[6]), int64(message[7]), int64(message[8]))
+}
+
+// MTCDecoder decodes a stream of MIDI bytes for MIDI time code. It reassembles the 8
+// quarter frame messages, playing forwards or backwards, and returns the timecode at the
+// last one: the timecode in the messages is of the first one sent, 2 frames before the
+// last one forwards and 2 frames after it backwards, so 2 frames are added or subtracted.
+// A full frame message is returned as is. Other MIDI messages are ignored.
+type MTCDecoder struct {
+	status   byte   // status of the message being read, 0 when none
+	sysEx    []byte // the system exclusive message being read
+	pieces   [8]int64
+	received int // number of pieces received in sequence
+	last     int // the last piece received
+	reverse  bool
+}
+
+// NewMTCDecoder Initializes a new MIDI time code decoder.
+func NewMTCDecoder() *MTCDecoder {
+	return &MTCDecoder{}
+}
+
+// Write Decodes MIDI bytes, and returns the timecode of each full frame message and of
+// each complete sequence of quarter frame messages in them, and the first error of a
+// message with an invalid timecode.
+func (d *MTCDecoder) Write(data []byte) ([]TimeCode, error) {
+	var ret []TimeCode
+	var first error
+	for _, b := range data {
+		tc, err := d.readByte(b)
+		if tc != nil {
+			ret = append(ret, *tc)
+		}
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return ret, first
+}
+
+// readByte Decodes a MIDI byte, and returns the timecode of the message it ends if any.
+func (d *MTCDecoder) readByte(b byte) (*TimeCode, error) {
+	switch {
+	case b >= 0xF8:
+		// system real time messages can be anywhere, even within other messages
+		return nil, nil
+	case b == _sysExEnd && d.status == _sysExStart:
+		d.status = 0
+		return d.fullFrame(append(d.sysEx, b))
+	case b >= 0x80:
+		d.status, d.sysEx = b, d.sysEx[:0]
+		if b == _sysExStart {
+			d.sysEx = append(d.sysEx, b)
+		}
+		return nil, nil
+	case d.status == _sysExStart:
+		// only full frame messages are kept
+		if len(d.sysEx) < 10 {
+			d.sysEx = append(d.sysEx, b)
+		}
+		return nil, nil
+	case d.status == _mtcQuarterFrame:
+		d.status = 0
+		return d.quarterFrame(int(b>>4), int64(b&0xF))
+	}
+	return nil, nil
+}
+
+// fullFrame Decodes a system exclusive message, and returns its timecode if it is a full
+// frame message.
+func (d *MTCDecoder) fullFrame(message []byte) (*TimeCode, error) {
+	if len(message) != 10 || message[1] != 0x7F || message[3] != 0x01 || message[4] != 0x01 {
+		return nil, nil
+	}
+	d.received = 0
+	return DecodeMTCFullFrame(message)
+}
+
+// quarterFrame Adds a piece of a quarter frame sequence, and returns the timecode when the
+// sequence is complete.
+func (d *MTCDecoder) quarterFrame(piece int, value int64) (*TimeCode, error) {
+	switch {
+	case d.received > 0 && piece == (d.last+1)%8 && !d.reverse:
+		d.received++
+	case d.received > 0 && piece == (d.last+7)%8 && (d.reverse || d.received == 1):
+		d.received++
+		d.reverse = true
+	default:
+		d.received, d.reverse = 1, false
+	}
+	d.last = piece
+	d.pieces[piece] = value
+	if d.received < 8 || (!d.reverse && piece != 7) || (d.reverse && piece != 0) {
+		return nil, nil
+	}
+	p := d.pieces
+	tc, err := mtcTimeCode(p[7]>>1, (p[7]&1)<<4|p[6], p[5]<<4|p[4], p[3]<<4|p[2], p[1]<<4|p[0])
+	if err != nil {
+		return nil, err
+	}
+	// the sequence took 2 frames, and the labels roll over at midnight
+	day := rateRecord(tc.FrameRate()).hours * 24
+	framecount := tc.TotalFrames() + 2
+	if d.reverse {
+		framecount = tc.TotalFrames() - 2 + day
+	}
+	return fromAbsoluteTime(framesToAbsoluteTime(framecount%day, tc.FrameRate()), tc.FrameRate())
+}
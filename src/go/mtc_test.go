@@ -0,0 +1,156 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mtcStream Returns the quarter frame messages of the timecodes, in reverse order of the
+// pieces and timecodes when reverse.
+func mtcStream(t *testing.T, timecodes []string, rate SmpteFrameRate, reverse bool) []byte {
+	var ret []byte
+	for i := range timecodes {
+		s := timecodes[i]
+		if reverse {
+			s = timecodes[len(timecodes)-1-i]
+		}
+		tc, _ := FromTimeCode(s, rate)
+		messages, err := EncodeMTCQuarterFrames(*tc)
+		assert.Nil(t, err, s)
+		for piece := range messages {
+			if reverse {
+				piece = 7 - piece
+			}
+			ret = append(ret, messages[piece][:]...)
+		}
+	}
+	return ret
+}
+
+// mtcLabels Returns the labels of the timecodes.
+func mtcLabels(timecodes []TimeCode) []string {
+	var ret []string
+	for _, tc := range timecodes {
+		ret = append(ret, tc.String())
+	}
+	return ret
+}
+
+func Test_EncodeMTCQuarterFrames(t *testing.T) {
+	tc, _ := FromTimeCode("17:42:39;27", Smpte2997Drop)
+	messages, err := EncodeMTCQuarterFrames(*tc)
+	assert.Nil(t, err)
+	assert.Equal(t, [8][2]byte{
+		{0xF1, 0x0B}, {0xF1, 0x11}, {0xF1, 0x27}, {0xF1, 0x32},
+		{0xF1, 0x4A}, {0xF1, 0x52}, {0xF1, 0x61}, {0xF1, 0x75},
+	}, messages)
+	for _, test := range []struct {
+		rate SmpteFrameRate
+		code byte
+	}{
+		{Smpte24, 0}, {Smpte2398, 0}, {Smpte25, 1}, {Smpte2997Drop, 2}, {Smpte2997NonDrop, 3}, {Smpte30, 3},
+	} {
+		tc, _ = FromTimeCode("00:00:00:00", test.rate)
+		messages, err = EncodeMTCQuarterFrames(*tc)
+		assert.Nil(t, err, test.rate.String())
+		assert.Equal(t, byte(0x70|test.code<<1), messages[7][1], test.rate.String())
+	}
+
+	tc, _ = FromTimeCode("00:00:00:00", Smpte50)
+	_, err = EncodeMTCQuarterFrames(*tc)
+	assert.NotNil(t, err)
+	tc, _ = FromTimeCode("-00:00:01:00", Smpte25)
+	_, err = EncodeMTCQuarterFrames(*tc)
+	assert.NotNil(t, err)
+	tc, _ = FromTimeCode("01:00:00:00:00", Smpte25)
+	_, err = EncodeMTCQuarterFrames(*tc)
+	assert.NotNil(t, err)
+}
+
+func Test_MTCFullFrame(t *testing.T) {
+	tc, _ := FromTimeCode("23:59:59:24", Smpte25)
+	message, err := EncodeMTCFullFrame(*tc)
+	assert.Nil(t, err)
+	assert.Equal(t, [10]byte{0xF0, 0x7F, 0x7F, 0x01, 0x01, 0x37, 59, 59, 24, 0xF7}, message)
+	back, err := DecodeMTCFullFrame(message[:])
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59:24", back.String())
+	assert.Equal(t, Smpte25, back.FrameRate())
+
+	// any device
+	message[2] = 0x10
+	back, err = DecodeMTCFullFrame(message[:])
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59:24", back.String())
+
+	_, err = DecodeMTCFullFrame(message[:9])
+	assert.NotNil(t, err)
+	message[8] = 25
+	_, err = DecodeMTCFullFrame(message[:])
+	assert.NotNil(t, err)
+	message[8] = 0x80
+	_, err = DecodeMTCFullFrame(message[:])
+	assert.NotNil(t, err)
+	// a dropped frame number
+	_, err = DecodeMTCFullFrame([]byte{0xF0, 0x7F, 0x7F, 0x01, 0x01, 0x40, 1, 0, 0, 0xF7})
+	assert.NotNil(t, err)
+}
+
+func Test_MTCDecoder(t *testing.T) {
+	// each sequence of quarter frames is 2 frames long, and the timecode of the sequence is
+	// at its first quarter frame, so the timecode at its last one is 2 frames later
+	d := NewMTCDecoder()
+	timecodes, err := d.Write(mtcStream(t, []string{"00:00:59;26", "00:00:59;28", "00:01:00;02", "00:01:00;04"}, Smpte2997Drop, false))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"00:00:59;28", "00:01:00;02", "00:01:00;04", "00:01:00;06"}, mtcLabels(timecodes))
+	assert.Equal(t, Smpte2997Drop, timecodes[0].FrameRate())
+
+	// backwards, the timecode at the last quarter frame is 2 frames earlier
+	d = NewMTCDecoder()
+	timecodes, err = d.Write(mtcStream(t, []string{"10:00:00:00", "10:00:00:02", "10:00:00:04"}, Smpte25, true))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10:00:00:02", "10:00:00:00", "09:59:59:23"}, mtcLabels(timecodes))
+
+	// the labels roll over at midnight
+	d = NewMTCDecoder()
+	timecodes, _ = d.Write(mtcStream(t, []string{"23:59:59:22"}, Smpte24, false))
+	assert.Equal(t, []string{"00:00:00:00"}, mtcLabels(timecodes))
+	d = NewMTCDecoder()
+	timecodes, _ = d.Write(mtcStream(t, []string{"00:00:00:01"}, Smpte30, true))
+	assert.Equal(t, []string{"23:59:59:29"}, mtcLabels(timecodes))
+
+	// a sequence is only complete from its first piece, in blocks, with real time messages
+	// and other messages between the quarter frames
+	stream := mtcStream(t, []string{"01:00:00:00", "01:00:00:02"}, Smpte25, false)
+	d = NewMTCDecoder()
+	var all []TimeCode
+	for i := 6; i < len(stream); i += 4 {
+		block := append([]byte{0xF8, 0x90, 0x3C, 0x7F}, stream[i:i+2]...)
+		block = append(block, 0xFE)
+		if i+2 < len(stream) {
+			block = append(block, stream[i+2], 0xF8, stream[i+3])
+		}
+		timecodes, err = d.Write(block)
+		assert.Nil(t, err)
+		all = append(all, timecodes...)
+	}
+	assert.Equal(t, []string{"01:00:00:04"}, mtcLabels(all))
+
+	// a full frame message, and a sequence broken by a missing piece
+	full, _ := FromTimeCode("02:00:00:00", Smpte30)
+	message, _ := EncodeMTCFullFrame(*full)
+	stream = append(message[:], 0xF0, 0x7E, 0x7F, 0x06, 0x01, 0xF7)
+	broken := mtcStream(t, []string{"02:00:00:00"}, Smpte30, false)
+	stream = append(stream, append(broken[:6], broken[8:]...)...)
+	d = NewMTCDecoder()
+	timecodes, err = d.Write(stream)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"02:00:00:00"}, mtcLabels(timecodes))
+
+	// an invalid timecode in a sequence
+	d = NewMTCDecoder()
+	timecodes, err = d.Write([]byte{0xF1, 0x0E, 0xF1, 0x11, 0xF1, 0x20, 0xF1, 0x30, 0xF1, 0x40, 0xF1, 0x50, 0xF1, 0x60, 0xF1, 0x72})
+	assert.NotNil(t, err)
+	assert.Nil(t, timecodes)
+}
@@ -0,0 +1,109 @@
+package timecode
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// SubFrameUnit enum type, the number of sub-frame units in a frame. Any positive
+// number of units can be used, e.g. SubFrameUnit(100) for hundredths of a frame.
+type SubFrameUnit int64
+
+const (
+	// SubFrameBits 1/80 of a frame, the bits of a linear timecode frame.
+	SubFrameBits SubFrameUnit = 80
+
+	// SubFramePerMille 1/1000 of a frame.
+	SubFramePerMille SubFrameUnit = 1000
+)
+
+// digits Returns the number of digits to format a sub-frame value of the unit.
+func (u SubFrameUnit) digits() int {
+	return len(strconv.FormatInt(int64(u)-1, 10))
+}
+
+// SubFrame Gets the exact fraction of a frame elapsed since the start of the frame of
+// this instance, from 0 up to but excluding 1. A negative duration gives the fraction
+// of its magnitude.
+func (m TimeCode) SubFrame() *big.Rat {
+	rec := rateRecord(m.frameRate)
+	frames := new(big.Rat).Mul(m.absolute(), newRat(rec.num, rec.den))
+	frames.Abs(frames)
+	return frames.Sub(frames, newRat(floorRat(frames), 1))
+}
+
+// SubFrameUnits Gets the whole number of sub-frame units elapsed since the start of the
+// frame of this instance, e.g. 40 for half a frame in SubFrameBits.
+func (m TimeCode) SubFrameUnits(unit SubFrameUnit) int64 {
+	return floorRat(new(big.Rat).Mul(m.SubFrame(), newRat(int64(unit), 1)))
+}
+
+// SubFrameSamples Gets the whole number of audio samples at the sample rate elapsed since
+// the start of the frame of this instance.
+func (m TimeCode) SubFrameSamples(sampleRate int64) int64 {
+	rec := rateRecord(m.frameRate)
+	return floorRat(new(big.Rat).Mul(m.SubFrame(), newRat(sampleRate*rec.den, rec.num)))
+}
+
+// StringSubFrame Returns the SMPTE 12M string representation of this instance with the
+// sub-frame units, e.g. "01:00:00:10.40" for half a frame in SubFrameBits.
+func (m TimeCode) StringSubFrame(unit SubFrameUnit) string {
+	return fmt.Sprintf("%s.%0*d", m.signedString(), unit.digits(), m.SubFrameUnits(unit))
+}
+
+// subFramesToAbsoluteTime Returns the absolute time of a number of sub-frame units.
+func subFramesToAbsoluteTime(subFrames int64, unit SubFrameUnit, rate SmpteFrameRate) *big.Rat {
+	rec := rateRecord(rate)
+	return newRat(subFrames*rec.den, int64(unit)*rec.num)
+}
+
+// FromSubFrameTimeCode Initializes a new instance of the TimeCode struct using a time code
+// string with sub-frame units, such as "01:00:00:10.40" in SubFrameBits. Without the
+// sub-frame units the time code is at the start of the frame.
+func FromSubFrameTimeCode(timeCode string, unit SubFrameUnit, rate SmpteFrameRate) (*TimeCode, error) {
+	if unit <= 0 {
+		return nil, fmt.Errorf("timecode: invalid sub-frame unit: '%v'", int64(unit))
+	}
+	subFrames := int64(0)
+	if index := strings.LastIndex(timeCode, "."); index >= 0 {
+		v, err := strconv.ParseInt(timeCode[index+1:], 10, 64)
+		if err != nil || v < 0 || v >= int64(unit) {
+			return nil, fmt.Errorf("timecode: invalid sub-frame: '%s'", timeCode)
+		}
+		timeCode, subFrames = timeCode[:index], v
+	}
+	tc, err := FromTimeCode(timeCode, rate)
+	if err != nil {
+		return nil, err
+	}
+	// the sub-frame is further from zero for a negative duration
+	if strings.HasPrefix(timeCode, "-") {
+		subFrames = -subFrames
+	}
+	return fromAbsoluteTime(new(big.Rat).Add(tc.absolute(), subFramesToAbsoluteTime(subFrames, unit, rate)), rate)
+}
+
+// PlusSubFrames returns a new TimeCode that is this instance plus a number of sub-frame units.
+func (m TimeCode) PlusSubFrames(subFrames int64, unit SubFrameUnit) (TimeCode, error) {
+	if unit <= 0 {
+		return m, fmt.Errorf("timecode: invalid sub-frame unit: '%v'", int64(unit))
+	}
+	return m.withAbsoluteTime(new(big.Rat).Add(m.absolute(), subFramesToAbsoluteTime(subFrames, unit, m.frameRate)))
+}
+
+// MinusSubFrames returns a new TimeCode that is this instance minus a number of sub-frame units.
+func (m TimeCode) MinusSubFrames(subFrames int64, unit SubFrameUnit) (TimeCode, error) {
+	return m.PlusSubFrames(-subFrames, unit)
+}
+
+// AddSubFrames ..
+func (m *TimeCode) AddSubFrames(subFrames int64, unit SubFrameUnit) error {
+	return m.assign(m.PlusSubFrames(subFrames, unit))
+}
+
+// SubSubFrames ..
+func (m *TimeCode) SubSubFrames(subFrames int64, unit SubFrameUnit) error {
+	return m.assign(m.MinusSubFrames(subFrames, unit))
+}
@@ -0,0 +1,85 @@
+package timecode
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SubFrame(t *testing.T) {
+	// the remainder of a time between frames is kept
+	tc, err := FromSeconds(1.01234, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00", tc.String())
+	assert.Equal(t, "0.3085", tc.SubFrame().FloatString(4))
+	assert.Equal(t, int64(24), tc.SubFrameUnits(SubFrameBits))
+	assert.Equal(t, int64(308), tc.SubFrameUnits(SubFramePerMille))
+	assert.Equal(t, int64(592), tc.SubFrameSamples(48000))
+	assert.Equal(t, "00:00:01:00.24", tc.StringSubFrame(SubFrameBits))
+	assert.Equal(t, "00:00:01:00.308", tc.StringSubFrame(SubFramePerMille))
+	assert.Equal(t, "00:00:01:00.30", tc.StringSubFrame(SubFrameUnit(100)))
+	// a frame has no sub-frame
+	tc, _ = FromFrames(10, Smpte2997Drop)
+	assert.Equal(t, 0, tc.SubFrame().Sign())
+	assert.Equal(t, "00:00:00;10.00", tc.StringSubFrame(SubFrameBits))
+	// half a frame of 29.97 is 800.8 samples at 48 kHz
+	tc, _ = FromSubFrameTimeCode("01:00:00;10.40", SubFrameBits, Smpte2997Drop)
+	assert.Equal(t, "1/2", tc.SubFrame().RatString())
+	assert.Equal(t, int64(800), tc.SubFrameSamples(48000))
+	assert.Equal(t, int64(10), tc.FramesSegment())
+}
+
+func Test_FromSubFrameTimeCode(t *testing.T) {
+	tc, err := FromSubFrameTimeCode("01:00:00:10.40", SubFrameBits, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:10.40", tc.StringSubFrame(SubFrameBits))
+	assert.Equal(t, "01:00:00:10.500", tc.StringSubFrame(SubFramePerMille))
+	assert.Equal(t, 0, tc.AbsoluteTime().Cmp(big.NewRat(3600*50+21, 50)))
+	tc, err = FromSubFrameTimeCode("01:00:00:10", SubFrameBits, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:10.00", tc.StringSubFrame(SubFrameBits))
+	// negative durations
+	tc, err = FromSubFrameTimeCode("-00:00:00:00.40", SubFrameBits, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "-1/50", tc.AbsoluteTime().RatString())
+	assert.Equal(t, "-00:00:00:00.40", tc.StringSubFrame(SubFrameBits))
+	tc, _ = FromSubFrameTimeCode("-00:00:02:03.079", SubFramePerMille, Smpte24)
+	assert.Equal(t, "-00:00:02:03.079", tc.StringSubFrame(SubFramePerMille))
+	// bad sub-frames
+	for _, timeCode := range []string{"00:00:01:00.80", "00:00:01:00.", "00:00:01:00.-1", "00:00:01:00.x"} {
+		_, err = FromSubFrameTimeCode(timeCode, SubFrameBits, Smpte25)
+		assert.NotNil(t, err, timeCode)
+	}
+	_, err = FromSubFrameTimeCode("00:00:01:00.0", SubFrameUnit(0), Smpte25)
+	assert.NotNil(t, err)
+}
+
+func Test_SubFrameArithmetic(t *testing.T) {
+	tc, _ := FromSubFrameTimeCode("00:00:00:24.60", SubFrameBits, Smpte25)
+	// frame arithmetic carries the sub-frame
+	v, err := tc.PlusFrames(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00.60", v.StringSubFrame(SubFrameBits))
+	v, _ = tc.PlusSeconds(1)
+	assert.Equal(t, "00:00:01:24.60", v.StringSubFrame(SubFrameBits))
+	// sub-frames carry into frames
+	v, err = tc.PlusSubFrames(30, SubFrameBits)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:00.10", v.StringSubFrame(SubFrameBits))
+	v, err = v.MinusSubFrames(11, SubFrameBits)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:24.79", v.StringSubFrame(SubFrameBits))
+	// sums of sub-frames are exact
+	sum, _ := FromFrames(0, Smpte2997NonDrop)
+	for i := 0; i < 3000; i++ {
+		err = sum.AddSubFrames(1, SubFramePerMille)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, "00:00:00:03.000", sum.StringSubFrame(SubFramePerMille))
+	err = sum.SubSubFrames(500, SubFramePerMille)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:02.500", sum.StringSubFrame(SubFramePerMille))
+	_, err = sum.PlusSubFrames(1, SubFrameUnit(-1))
+	assert.NotNil(t, err)
+}
@@ -11,243 +11,36 @@ import (
 	"time"
 )
 
-const _defaultPrecision = 106
-
-func newBF(v float64) *big.Float {
-	ret := new(big.Float).SetPrec(_defaultPrecision)
-	if v != 0 {
-		// s := fmt.Sprintf("%g", v)
-		// ret.SetString(s)
-		ret.SetFloat64(v)
-	}
-	return ret
-}
-
-func newBFInt64(v int64) *big.Float {
-	return newBF(0).SetInt64(v)
-}
-
-func newBFString(v string) *big.Float {
-	ret, _ := newBF(0).SetString(v)
-	return ret
-}
-
-func addBF(v1, v2 *big.Float) *big.Float {
-	return newBF(0).Add(v1, v2)
-}
-func subBF(v1, v2 *big.Float) *big.Float {
-	return newBF(0).Sub(v1, v2)
-}
-
-func mulBF(v1, v2 *big.Float) *big.Float {
-	return newBF(0).Mul(v1, v2)
-}
-func divBF(v1, v2 *big.Float) *big.Float {
-	return newBF(0).Quo(v1, v2)
-}
-
-func roundBF(val *big.Float, decimals int) *big.Float {
-	delta, pow := newBF(0), newBF(0).SetInt64(int64(math.Pow10(decimals)))
-	if val.Sign() >= 0 {
-		delta.SetString("0.5")
-	} else {
-		delta.SetString("-0.5")
-	}
-	ret := addBF(mulBF(val, pow), delta)
-	intVal, _ := ret.Int(nil)
-	return ret.SetInt(intVal).Quo(ret, pow)
-}
-
-// decimal ..
-type decimal struct {
-	value *big.Float
-}
-
-// newDecimal create a new decimal with float64
-func newDecimal(value *big.Float) *decimal {
-	return &decimal{
-		value: value,
-	}
-}
-
-func newDecimalFloat64(value float64) *decimal {
-	return &decimal{
-		value: newBF(value),
-	}
-}
-
-// newDecimalString create a new decimal with float64
-func newDecimalString(value string) *decimal {
-	return &decimal{
-		value: newBFString(value),
-	}
-}
-
-// newDecimalInt64 create a new decimal with float64
-func newDecimalInt64(value int64) *decimal {
-	return &decimal{
-		value: newBFInt64(value),
-	}
-}
-
-/*
-func addDecimal(v1, v2 *decimal) *decimal {
-	return &decimal{
-		value: newBF(0).Add(v1.value, v2.value),
-	}
-}
-
-func subDecimal(v1, v2 *decimal) *decimal {
-	return &decimal{
-		value: newBF(0).Sub(v1.value, v2.value),
-	}
-}
-
-func mulDecimal(v1, v2 *decimal) *decimal {
-	return &decimal{
-		value: newBF(0).Mul(v1.value, v2.value),
-	}
-}
-
-func divDecimal(v1, v2 *decimal) *decimal {
-	return &decimal{
-		value: newBF(0).Quo(v1.value, v2.value),
-	}
-}
-*/
-// addFloat add a float value
-func (m *decimal) addFloat(v *big.Float) *decimal {
-	return newDecimal(addBF(m.value, v))
-}
-
-// subFloat sub a float value
-func (m *decimal) subFloat(v *big.Float) *decimal {
-	return newDecimal(subBF(m.value, v))
-}
-
-// mulFloat mul a float value
-func (m *decimal) mulFloat(v *big.Float) *decimal {
-	return newDecimal(mulBF(m.value, v))
-}
-
-// divFloat div a float value
-func (m *decimal) divFloat(v *big.Float) *decimal {
-	return newDecimal(divBF(m.value, v))
-}
-
-// Copy ..
-// func (m *decimal) Copy() *decimal {
-// 	ret := newBF(0)
-// 	return &decimal{
-// 		value: ret.Add(ret, m.value),
-// 	}
-// }
-
-// Float ..
-func (m *decimal) Float() *big.Float {
-	return m.value
-}
-
-// Add ..
-func (m *decimal) Add(v *decimal) *decimal {
-	return newDecimal(addBF(m.value, v.value))
-}
-
-// AddFloat64 ..
-func (m *decimal) AddFloat64(v float64) *decimal {
-	return newDecimal(addBF(m.value, newBF(v)))
-}
-
-// Sub ..
-func (m *decimal) Sub(v *decimal) *decimal {
-	return newDecimal(subBF(m.value, v.value))
-}
-
-// Mul multiply a decimal value
-func (m *decimal) Mul(v *decimal) *decimal {
-	return newDecimal(mulBF(m.value, v.value))
-}
-
-// MulFloat64 ..
-func (m *decimal) MulInt64(v int64) *decimal {
-	return newDecimal(mulBF(m.value, newBFInt64(v)))
-}
-
-// MulFloat64 ..
-func (m *decimal) MulFloat64(v float64) *decimal {
-	return newDecimal(mulBF(m.value, newBF(v)))
-}
-
-// MulFloat32 multiply a float32 value
-func (m *decimal) MulFloat32(v float32) *decimal {
-	return newDecimal(mulBF(m.value, newBF(float64(v))))
-}
-
-// Div ..
-func (m *decimal) Div(v *decimal) *decimal {
-	return newDecimal(divBF(m.value, v.value))
-}
-
-// DivFloat64 ..
-func (m *decimal) DivFloat64(v float64) *decimal {
-	return newDecimal(divBF(m.value, newBF(v)))
+// newRat creates a rational number num/den.
+func newRat(num, den int64) *big.Rat {
+	return big.NewRat(num, den)
 }
 
-// DivInt64 ..
-func (m *decimal) DivInt64(v int64) *decimal {
-	return newDecimal(divBF(m.value, newBFInt64(v)))
+// floorRat returns the largest integer less than or equal to v.
+func floorRat(v *big.Rat) int64 {
+	// big.Int.Div is euclidean, the denominator of a big.Rat is always positive
+	return new(big.Int).Div(v.Num(), v.Denom()).Int64()
 }
 
-// Floor ..
-func (m *decimal) Floor() *decimal {
-	intVal, _ := m.value.Int(nil)
-	return newDecimal(newBF(0).SetInt(intVal))
-}
-
-func (m *decimal) Round(decimals int) *decimal {
-	val, delta := newBF(0), newBF(0)
-	pow := newBF(0).SetInt64(int64(math.Pow10(decimals)))
-	if m.value.Sign() >= 0 {
-		delta.SetString("0.5")
+// roundRat rounds v half away from zero to the number of decimals.
+func roundRat(v *big.Rat, decimals int) *big.Rat {
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	ret := new(big.Rat).Mul(v, new(big.Rat).SetInt(pow))
+	if ret.Sign() >= 0 {
+		ret.Add(ret, newRat(1, 2))
 	} else {
-		delta.SetString("-0.5")
+		ret.Sub(ret, newRat(1, 2))
 	}
-	intVal, _ := val.Mul(m.value, pow).Add(val, delta).Int(nil)
-	ret := val.SetInt(intVal)
-	ret = ret.Quo(ret, pow)
-	// return
-	return newDecimal(ret)
-}
-
-// Int64 ..
-func (m *decimal) Int64() int64 {
-	ret, _ := m.value.Int64()
-	return ret
-}
-
-// Float32 ..
-func (m *decimal) Float32() float32 {
-	ret, _ := m.value.Float32()
-	return ret
+	intVal := new(big.Int).Quo(ret.Num(), ret.Denom())
+	return ret.SetFrac(intVal, pow)
 }
 
-// Float64 ..
-func (m *decimal) Float64() float64 {
-	ret, _ := m.value.Float64()
+// ratFloat64 returns the nearest float64 value of v.
+func ratFloat64(v *big.Rat) float64 {
+	ret, _ := v.Float64()
 	return ret
 }
 
-// String ..
-func (m *decimal) String() string {
-	return m.value.String()
-}
-
-// Debug ..
-func (m *decimal) Debug() {
-	s := fmt.Sprintf("value: %g; %s", m.value, m.value.String())
-	fmt.Println(s)
-}
-
 // SmpteFrameRate enum type
 type SmpteFrameRate int
 
@@ -291,24 +84,88 @@ const (
 	// Smpte120 120 fps frame rate.
 	Smpte120 SmpteFrameRate = 12
 
+	// Smpte4795 47.952 fps frame rate. Film Sync at twice the frame rate.
+	Smpte4795 SmpteFrameRate = 13
+
+	// Smpte9590 95.904 fps frame rate. Film Sync at four times the frame rate.
+	Smpte9590 SmpteFrameRate = 14
+
+	// Smpte11988Drop 119.88 fps Drop Frame timecode.
+	Smpte11988Drop SmpteFrameRate = 15
+
+	// Smpte11988NonDrop 119.88 fps Non Drop Frame timecode.
+	Smpte11988NonDrop SmpteFrameRate = 16
+
+	// Smpte30Drop 30 fps Drop Frame timecode. Used by some MIDI time code and audio equipment.
+	Smpte30Drop SmpteFrameRate = 17
+
 	// Unknown Value.
 	Unknown SmpteFrameRate = -1
 )
 
+// OverflowPolicy enum type, the way arithmetic results outside of MinValue and MaxValue are handled.
+type OverflowPolicy int
+
+const (
+	// OverflowError returns an error for results out of range. This is the default.
+	OverflowError OverflowPolicy = 0
+
+	// OverflowClamp clamps results to MinValue or MaxValue.
+	OverflowClamp OverflowPolicy = 1
+
+	// OverflowWrap wraps results modulo 24 hours, like a time-of-day clock.
+	OverflowWrap OverflowPolicy = 2
+
+	// OverflowSigned allows negative durations down to -MaxValue, and returns an
+	// error for results beyond MaxValue in either direction.
+	OverflowSigned OverflowPolicy = 3
+)
+
+// Rounding enum type, the way a time between two frames is quantised to a frame.
+type Rounding int
+
+const (
+	// RoundFloor quantises to the frame at or before the time.
+	RoundFloor Rounding = 0
+
+	// RoundNearest quantises to the nearest frame, halves are rounded away from zero.
+	RoundNearest Rounding = 1
+
+	// RoundCeil quantises to the frame at or after the time.
+	RoundCeil Rounding = 2
+)
+
+// LabelPolicy enum type, the way a label that does not exist at a frame rate is handled.
+type LabelPolicy int
+
+const (
+	// LabelError returns an error for a label that does not exist at the frame rate.
+	LabelError LabelPolicy = 0
+
+	// LabelSnap moves a dropped frame number forward to the next label that exists,
+	// e.g. 00:01:00;00 becomes 00:01:00;02 at 29.97 drop frame.
+	LabelSnap LabelPolicy = 1
+)
+
 const (
 	_smpte12MBadFormat        = "The timecode provided is not in the correct format."
 	_smpte12MOutOfRange       = "The timecode provided is out of the expected range."
 	_smpte12MMaxValueOverflow = "The resulting timecode %v is out of the expected range of MaxValue %v."
 	_smpte12MMinValueOverflow = "The resulting timecode is out of the expected range of MinValue."
+	_smpte12MDroppedFrame     = "The timecode %v is a dropped frame number at %v."
+	_unknownFrameRate         = "timecode: unknown frame rate: '%v'"
 )
 
 var (
 	/// Regular expression object used for validating timecode.
 	// SMPTEREGEXSTRING Regular expression string used for parsing out the timecode.
-	_validateTimecode *regexp.Regexp = regexp.MustCompile("(\\d{2}):(\\d{2}):(\\d{2})(?::|;)(\\d{2})")
-	_epsilon          *decimal       = newDecimalString("0.00000000000000000000001")
-	_1000div1001      *decimal       = newDecimal(divBF(newBF(1000), newBF(1001)))
-	_1001div1000      *decimal       = newDecimal(divBF(newBF(1001), newBF(1000)))
+	_validateTimecode *regexp.Regexp = regexp.MustCompile("^(?:\\d+:)?(\\d{2}):(\\d{2}):(\\d{2})(?::|;)(\\d{2,3})$")
+	// _floatTolerance is the relative error within which a float64 time is taken as lying
+	// exactly on a frame boundary. A float64 cannot hold NTSC frame times such as
+	// 1001/30000 exactly, so such values are snapped to the frame. It covers the rounding
+	// of float64 arithmetic and of seconds printed to the microsecond, such as 5797.133333
+	// for frame 173914 at 30 fps, but not an offset of a microsecond from a frame.
+	_floatTolerance = newRat(1, 10000000000)
 )
 
 type rateRec struct {
@@ -316,51 +173,73 @@ type rateRec struct {
 	hours   int64
 	minutes int64
 	rate    float64
-	drop    bool
-	pcrTb   float64
-	max     *decimal
+	// drop is the number of frame numbers dropped at the start of every minute,
+	// except every tenth minute, or zero for non drop frame timecode
+	drop int64
+	// num/den is the exact number of frames per second
+	num int64
+	den int64
+	// name is the display name of the frame rate
+	name string
 }
 
 var _rateRecords = map[SmpteFrameRate]*rateRec{
-	Smpte2398:        &rateRec{frames: 24, hours: 86400, minutes: 1440, rate: 23.98, drop: false, pcrTb: 3753.75, max: newDecimalString("86486.358291666700000")},
-	Smpte24:          &rateRec{frames: 24, hours: 86400, minutes: 1440, rate: 24, drop: false, pcrTb: 3750, max: newDecimalString("86399.958333333300000")},
-	Smpte25:          &rateRec{frames: 25, hours: 90000, minutes: 1500, rate: 25, drop: false, pcrTb: 3600, max: newDecimalString("86399.960000000000000")},
-	Smpte2997Drop:    &rateRec{frames: 30, hours: 107892, minutes: 1798, rate: 29.97, drop: true, pcrTb: 3003, max: newDecimalString("86399.880233333300000")},
-	Smpte2997NonDrop: &rateRec{frames: 30, hours: 108000, minutes: 1800, rate: 29.97, drop: false, pcrTb: 3003, max: newDecimalString("86486.366633333300000")},
-	Smpte30:          &rateRec{frames: 30, hours: 108000, minutes: 1800, rate: 30, drop: false, pcrTb: 3000, max: newDecimalString("86399.966666666700000")},
-	Smpte50:          &rateRec{frames: 50, hours: 180000, minutes: 3000, rate: 50, drop: false, max: newDecimalString("86399.980000000000000")},
-	Smpte5994Drop:    &rateRec{frames: 60, hours: 215784, minutes: 3596, rate: 59.94, drop: true, max: newDecimalString("86399.896916666700000")},
-	Smpte5994NonDrop: &rateRec{frames: 60, hours: 216000, minutes: 3600, rate: 59.94, drop: false, max: newDecimalString("86486.383316670000000")},
-	Smpte60:          &rateRec{frames: 60, hours: 216000, minutes: 3600, rate: 60, drop: false, max: newDecimalString("86399.983333333300000")},
-	Smpte96:          &rateRec{frames: 96, hours: 345600, minutes: 5760, rate: 96, drop: false, max: newDecimalString("86399.983333333300000")},
-	Smpte100:         &rateRec{frames: 100, hours: 360000, minutes: 6000, rate: 100, drop: false, max: newDecimalString("86399.983333333300000")},
-	Smpte120:         &rateRec{frames: 120, hours: 432000, minutes: 7200, rate: 120, drop: false, max: newDecimalString("86399.983333333300000")},
+	Smpte2398:         &rateRec{frames: 24, hours: 86400, minutes: 1440, rate: 23.98, drop: 0, num: 24000, den: 1001, name: "23.98"},
+	Smpte24:           &rateRec{frames: 24, hours: 86400, minutes: 1440, rate: 24, drop: 0, num: 24, den: 1, name: "24"},
+	Smpte25:           &rateRec{frames: 25, hours: 90000, minutes: 1500, rate: 25, drop: 0, num: 25, den: 1, name: "25"},
+	Smpte2997Drop:     &rateRec{frames: 30, hours: 107892, minutes: 1798, rate: 29.97, drop: 2, num: 30000, den: 1001, name: "29.97 DF"},
+	Smpte2997NonDrop:  &rateRec{frames: 30, hours: 108000, minutes: 1800, rate: 29.97, drop: 0, num: 30000, den: 1001, name: "29.97 NDF"},
+	Smpte30:           &rateRec{frames: 30, hours: 108000, minutes: 1800, rate: 30, drop: 0, num: 30, den: 1, name: "30"},
+	Smpte50:           &rateRec{frames: 50, hours: 180000, minutes: 3000, rate: 50, drop: 0, num: 50, den: 1, name: "50"},
+	Smpte5994Drop:     &rateRec{frames: 60, hours: 215784, minutes: 3596, rate: 59.94, drop: 4, num: 60000, den: 1001, name: "59.94 DF"},
+	Smpte5994NonDrop:  &rateRec{frames: 60, hours: 216000, minutes: 3600, rate: 59.94, drop: 0, num: 60000, den: 1001, name: "59.94 NDF"},
+	Smpte60:           &rateRec{frames: 60, hours: 216000, minutes: 3600, rate: 60, drop: 0, num: 60, den: 1, name: "60"},
+	Smpte96:           &rateRec{frames: 96, hours: 345600, minutes: 5760, rate: 96, drop: 0, num: 96, den: 1, name: "96"},
+	Smpte100:          &rateRec{frames: 100, hours: 360000, minutes: 6000, rate: 100, drop: 0, num: 100, den: 1, name: "100"},
+	Smpte120:          &rateRec{frames: 120, hours: 432000, minutes: 7200, rate: 120, drop: 0, num: 120, den: 1, name: "120"},
+	Smpte4795:         &rateRec{frames: 48, hours: 172800, minutes: 2880, rate: 47.95, drop: 0, num: 48000, den: 1001, name: "47.95"},
+	Smpte9590:         &rateRec{frames: 96, hours: 345600, minutes: 5760, rate: 95.9, drop: 0, num: 96000, den: 1001, name: "95.9"},
+	Smpte11988Drop:    &rateRec{frames: 120, hours: 431568, minutes: 7192, rate: 119.88, drop: 8, num: 120000, den: 1001, name: "119.88 DF"},
+	Smpte11988NonDrop: &rateRec{frames: 120, hours: 432000, minutes: 7200, rate: 119.88, drop: 0, num: 120000, den: 1001, name: "119.88 NDF"},
+	Smpte30Drop:       &rateRec{frames: 30, hours: 107892, minutes: 1798, rate: 30, drop: 2, num: 30, den: 1, name: "30 DF"},
 }
 
 // TimeCode ...
 type TimeCode struct {
-	/// The private Timespan used to track absolute time for this instance.
-	absoluteTime *decimal
+	/// The private exact number of seconds used to track absolute time for this instance.
+	absoluteTime *big.Rat
 
 	/// The frame rate for this instance.
 	frameRate SmpteFrameRate
+
+	/// The policy applied when arithmetic leaves the range of MinValue to MaxValue.
+	overflow OverflowPolicy
+
+	/// The field of an interlaced frame that comes first in time.
+	dominance FieldDominance
 }
 
-func fromTimeDecimal(time *decimal, rate SmpteFrameRate) (*TimeCode, error) {
+func fromAbsoluteTime(time *big.Rat, rate SmpteFrameRate) (*TimeCode, error) {
+	if _, ok := lookupRate(rate); !ok {
+		return nil, fmt.Errorf(_unknownFrameRate, rate)
+	}
 	return &TimeCode{
 		frameRate:    rate,
 		absoluteTime: time,
 	}, nil
 }
 
+// absolute returns the absolute time of this instance, the zero TimeCode is at 0 seconds.
+func (m TimeCode) absolute() *big.Rat {
+	if m.absoluteTime == nil {
+		return new(big.Rat)
+	}
+	return m.absoluteTime
+}
+
 //FromTimeHours  Initializes a new instance of the TimeCode struct to a specified number of hours, minutes, and seconds.
 func FromTimeHours(hours, minutes, seconds, frames int, rate SmpteFrameRate) (*TimeCode, error) {
-	timeCode := fmt.Sprintf("%02d:%02d:%02d:%02d", hours, minutes, seconds, frames)
-	time, err := smpte12mToAbsoluteTime(timeCode, rate)
-	if err != nil {
-		return nil, err
-	}
-	return fromTimeDecimal(time, rate)
+	return FromTimeDays(0, hours, minutes, seconds, frames, rate)
 }
 
 // FromTimeDays ..
@@ -379,12 +258,12 @@ func FromTimeHours(hours, minutes, seconds, frames int, rate SmpteFrameRate) (*T
 /// </exception>
 /// <code source="..\Documentation\SdkDocSamples\TimecodeSamples.cs" region="CreateTimeCode_2398FromIntegers" lang="CSharp" title="Create TimeCode from Integers"/>
 func FromTimeDays(days, hours, minutes, seconds, frames int, rate SmpteFrameRate) (*TimeCode, error) {
-	timeCode := fmt.Sprintf("%02d:%02d:%02d:%02d:%02d", days, hours, minutes, seconds, frames)
-	time, err := smpte12mToAbsoluteTime(timeCode, rate)
+	err := validateSegments(int64(days), int64(hours), int64(minutes), int64(seconds), int64(frames), rate)
 	if err != nil {
 		return nil, err
 	}
-	return fromTimeDecimal(time, rate)
+	framecount := segmentsToFrames(int64(days), int64(hours), int64(minutes), int64(seconds), int64(frames), rate)
+	return fromAbsoluteTime(framesToAbsoluteTime(framecount, rate), rate)
 }
 
 /*
@@ -422,7 +301,8 @@ func FromTimeDays(days, hours, minutes, seconds, frames int, rate SmpteFrameRate
 /// </summary>
 /// <remarks>
 /// Pass in a timecode in the format "timecode@framrate".
-/// Supported rates include @23.98, @24, @25, @29.97, @30
+/// The rate is parsed by ParseRate, e.g. @23.976, @25, @29.97DF, @24000/1001, @59.94i.
+/// When it has no DF or NDF suffix, a ';' separator in the time code means drop frame.
 /// </remarks>
 /// <example>
 /// "00:01:00:00@29.97" is equivalent to 29.97 non drop frame.
@@ -431,23 +311,29 @@ func FromTimeDays(days, hours, minutes, seconds, frames int, rate SmpteFrameRate
 /// <param name="timeCodeAndRate">The SMPTE 12m time code string.</param>
 func FromTimeCodeRate(timeCodeAndRate string) (*TimeCode, error) {
 	timeAndRate := strings.Split(timeCodeAndRate, "@")
-	timeCode, frameRate := "", 29.07
+	timeCode, frameRate := "", "29.97"
 
 	if len(timeAndRate) == 1 {
 		timeCode = timeAndRate[0]
 	} else if len(timeAndRate) == 2 {
 		timeCode = timeAndRate[0]
-		frameRate, _ = strconv.ParseFloat(timeAndRate[1], 10)
+		frameRate = timeAndRate[1]
+	} else {
+		return nil, fmt.Errorf("timecode: invalid timecode with rate: '%s'", timeCodeAndRate)
 	}
-	drop := strings.Index(timeCode, ";") >= 0
-	// try to find match record
-	for rate, rec := range _rateRecords {
-		if rec.rate == frameRate && rec.drop == drop {
-			return FromTimeCode(timeCode, rate)
-		}
+	fps, drop, explicit, err := parseRate(frameRate)
+	if err != nil {
+		return nil, fmt.Errorf("timecode: invalid timecode with rate: '%s'", timeCodeAndRate)
 	}
-	// now return error
-	return nil, fmt.Errorf("timecode: invalid timecode with rate: '%s'", timeCodeAndRate)
+	// without a DF or NDF suffix drop frame is taken from the separator
+	if !explicit {
+		drop = strings.Index(timeCode, ";") >= 0
+	}
+	rate := RateFromRational(fps, drop)
+	if rate == Unknown {
+		return nil, fmt.Errorf("timecode: invalid timecode with rate: '%s'", timeCodeAndRate)
+	}
+	return FromTimeCode(timeCode, rate)
 }
 
 //FromTimeCode Initializes a new instance of the TimeCode struct using a time code string and a SMPTE framerate.
@@ -456,12 +342,29 @@ func FromTimeCode(timeCode string, rate SmpteFrameRate) (*TimeCode, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromTimeDecimal(time, rate)
+	return fromAbsoluteTime(time, rate)
+}
+
+// Reinterpret Initializes a new instance of the TimeCode struct with the label of the
+// timecode at another SMPTE framerate, see TimeCode.Reinterpret.
+func Reinterpret(tc *TimeCode, rate SmpteFrameRate, policy LabelPolicy) (*TimeCode, error) {
+	ret, err := tc.Reinterpret(rate, policy)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
 }
 
 // FromTime Initializes a new instance of the TimeCode struct using an absolute time value, and the SMPTE framerate.
 func FromTime(absoluteTime float64, rate SmpteFrameRate) (*TimeCode, error) {
-	return fromTimeDecimal(newDecimalFloat64(absoluteTime), rate)
+	if _, ok := lookupRate(rate); !ok {
+		return nil, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	time, err := float64ToAbsoluteTime(absoluteTime, rate)
+	if err != nil {
+		return nil, err
+	}
+	return fromAbsoluteTime(time, rate)
 }
 
 // TicksPerDay ..
@@ -551,8 +454,8 @@ const MinValue float64 = 0
 ///  A double that is the absolute time in seconds duration of the current TimeCode object.
 /// </returns>
 /// <value>The absolute time in seconds of the current TimeCode.</value>
-func (m *TimeCode) Duration() float64 {
-	return m.absoluteTime.Float64()
+func (m TimeCode) Duration() float64 {
+	return ratFloat64(m.absolute())
 }
 
 // FrameRate ..
@@ -560,7 +463,7 @@ func (m *TimeCode) Duration() float64 {
 /// Gets or sets the current SMPTE framerate for this TimeCode instance.
 /// </summary>
 /// <value>The frame rate of the TimeCode.</value>
-func (m *TimeCode) FrameRate() SmpteFrameRate {
+func (m TimeCode) FrameRate() SmpteFrameRate {
 	return m.frameRate
 }
 
@@ -573,18 +476,9 @@ func (m *TimeCode) FrameRate() SmpteFrameRate {
 ///  The hour component of the current TimeCode structure.
 /// </returns>
 /// <value>The number of whole days of the TimeCode.</value>
-func (m *TimeCode) DaysSegment() int64 {
-	timeCode := absoluteTimeToSmpte12M(m.absoluteTime, m.frameRate)
-
-	days := "0"
-
-	if len(timeCode) > 11 {
-		index := strings.Index(timeCode, ":")
-		days = timeCode[0:index]
-	}
-
-	ret, _ := strconv.ParseInt(days, 10, 64)
-	return ret
+func (m TimeCode) DaysSegment() int64 {
+	days, _, _, _, _ := m.segments()
+	return days
 }
 
 // HoursSegment ..
@@ -597,18 +491,9 @@ func (m *TimeCode) DaysSegment() int64 {
 ///     ranges from 0 through 23.
 /// </returns>
 /// <value>The number of whole hours of the TimeCode.</value>
-func (m *TimeCode) HoursSegment() int64 {
-	timeCode := absoluteTimeToSmpte12M(m.absoluteTime, m.frameRate)
-
-	if len(timeCode) > 11 {
-		index := strings.Index(timeCode, ":") + 1
-		timeCode = timeCode[index:]
-	}
-
-	hours := timeCode[0:2]
-
-	ret, _ := strconv.ParseInt(hours, 10, 64)
-	return ret
+func (m TimeCode) HoursSegment() int64 {
+	_, hours, _, _, _ := m.segments()
+	return hours
 }
 
 // MinutesSegment ..
@@ -620,18 +505,9 @@ func (m *TimeCode) HoursSegment() int64 {
 /// value ranges from 0 through 59.
 /// </returns>
 /// <value>The number of whole minutes of the current TimeCode.</value>
-func (m *TimeCode) MinutesSegment() int64 {
-	timeCode := absoluteTimeToSmpte12M(m.absoluteTime, m.frameRate)
-
-	if len(timeCode) > 11 {
-		index := strings.Index(timeCode, ":") + 1
-		timeCode = timeCode[index:]
-	}
-
-	minutes := timeCode[3:5]
-
-	ret, _ := strconv.ParseInt(minutes, 10, 64)
-	return ret
+func (m TimeCode) MinutesSegment() int64 {
+	_, _, minutes, _, _ := m.segments()
+	return minutes
 }
 
 // SecondsSegment ..
@@ -643,18 +519,9 @@ func (m *TimeCode) MinutesSegment() int64 {
 ///    value ranges from 0 through 59.
 /// </returns>
 /// <value>The number of whole seconds of the current TimeCode.</value>
-func (m *TimeCode) SecondsSegment() int64 {
-	timeCode := absoluteTimeToSmpte12M(m.absoluteTime, m.frameRate)
-
-	if len(timeCode) > 11 {
-		index := strings.Index(timeCode, ":") + 1
-		timeCode = timeCode[index:]
-	}
-
-	seconds := timeCode[6:8]
-
-	ret, _ := strconv.ParseInt(seconds, 10, 64)
-	return ret
+func (m TimeCode) SecondsSegment() int64 {
+	_, _, _, seconds, _ := m.segments()
+	return seconds
 }
 
 // FramesSegment ..
@@ -667,18 +534,35 @@ func (m *TimeCode) SecondsSegment() int64 {
 ///     value depends on the framerate selected for this instance. All frame counts start at zero.
 /// </returns>
 /// <value>The number of whole frames of the TimeCode.</value>
-func (m *TimeCode) FramesSegment() int64 {
-	timeCode := absoluteTimeToSmpte12M(m.absoluteTime, m.frameRate)
+func (m TimeCode) FramesSegment() int64 {
+	_, _, _, _, frames := m.segments()
+	return frames
+}
 
-	if len(timeCode) > 11 {
-		index := strings.Index(timeCode, ":") + 1
-		timeCode = timeCode[index:]
+// segments returns the segments of the SMPTE 12M label, without the sign of negative durations.
+func (m TimeCode) segments() (days, hours, minutes, seconds, frames int64) {
+	framecount := m.TotalFrames()
+	if framecount < 0 {
+		framecount = -framecount
 	}
+	return framesToSegments(framecount, m.frameRate)
+}
+
+// Negative Indicates whether the current TimeCode is a negative duration.
+func (m TimeCode) Negative() bool {
+	return m.absolute().Sign() < 0
+}
 
-	frames := timeCode[9:11]
+// Neg returns a new TimeCode that is the opposite duration of this instance.
+func (m TimeCode) Neg() TimeCode {
+	m.absoluteTime = new(big.Rat).Neg(m.absolute())
+	return m
+}
 
-	ret, _ := strconv.ParseInt(frames, 10, 64)
-	return ret
+// Abs returns a new TimeCode that is the absolute duration of this instance.
+func (m TimeCode) Abs() TimeCode {
+	m.absoluteTime = new(big.Rat).Abs(m.absolute())
+	return m
 }
 
 // TotalDays ..
@@ -690,9 +574,11 @@ func (m *TimeCode) FramesSegment() int64 {
 ///  The total number of days represented by this instance.
 /// </returns>
 /// <value>The number of days of the TimeCode.</value>
-func (m *TimeCode) TotalDays() float64 {
-	framecount := absoluteTimeToFrames(m.absoluteTime, m.frameRate)
-	return (float64(framecount) / 108000) / 24
+func (m TimeCode) TotalDays() float64 {
+	framecount := absoluteTimeToFrames(m.absolute(), m.frameRate)
+	rec := rateRecord(m.FrameRate())
+
+	return (float64(framecount) / float64(rec.hours)) / 24
 }
 
 // TotalHours ...
@@ -704,9 +590,9 @@ func (m *TimeCode) TotalDays() float64 {
 ///  The total number of hours represented by this instance.
 /// </returns>
 /// <value>The number of hours of the TimeCode.</value>
-func (m *TimeCode) TotalHours() float64 {
-	framecount := absoluteTimeToFrames(m.absoluteTime, m.frameRate)
-	rec := _rateRecords[m.FrameRate()]
+func (m TimeCode) TotalHours() float64 {
+	framecount := absoluteTimeToFrames(m.absolute(), m.frameRate)
+	rec := rateRecord(m.FrameRate())
 
 	return float64(framecount) / float64(rec.hours)
 }
@@ -720,88 +606,81 @@ func (m *TimeCode) TotalHours() float64 {
 ///  The total number of minutes represented by this instance.
 /// </returns>
 /// <value>The number of minutes of the TimeCode.</value>
-func (m *TimeCode) TotalMinutes() float64 {
-	framecount := absoluteTimeToFrames(m.absoluteTime, m.frameRate)
-	rec := _rateRecords[m.FrameRate()]
+func (m TimeCode) TotalMinutes() float64 {
+	framecount := absoluteTimeToFrames(m.absolute(), m.frameRate)
+	rec := rateRecord(m.FrameRate())
 
 	return float64(framecount) / float64(rec.minutes)
 }
 
 //TotalSeconds Gets the value of the current TimeCode structure expressed in whole
 /// and fractional seconds. Not as Precise as the TotalSecondsPrecision.
-func (m *TimeCode) TotalSeconds() float64 {
-	return m.absoluteTime.Round(7).Float64()
+func (m TimeCode) TotalSeconds() float64 {
+	return ratFloat64(roundRat(m.absolute(), 7))
 }
 
 //TotalSecondsPrecision Gets the value of the current TimeCode structure expressed in whole
-/// and fractional seconds. This is returned as a <see cref="decimal"/> for greater precision.
-func (m *TimeCode) TotalSecondsPrecision() float64 {
-	return m.absoluteTime.Float64()
+/// and fractional seconds. This is the nearest float64 to the exact absolute time.
+func (m TimeCode) TotalSecondsPrecision() float64 {
+	return ratFloat64(m.absolute())
+}
+
+//AbsoluteTime Gets the exact value of the current TimeCode structure expressed in seconds.
+func (m TimeCode) AbsoluteTime() *big.Rat {
+	return new(big.Rat).Set(m.absolute())
 }
 
 //TotalFrames Gets the value of the current TimeCode structure expressed in frames.
-func (m *TimeCode) TotalFrames() int64 {
-	return absoluteTimeToFrames(m.absoluteTime, m.frameRate)
+func (m TimeCode) TotalFrames() int64 {
+	return absoluteTimeToFrames(m.absolute(), m.frameRate)
 }
 
 //MaxValue Gets the maximum TimeCode value of a known frame rate. The Max value for Timecode.
-func maxValue(frameRate SmpteFrameRate) *decimal {
-	return _rateRecords[frameRate].max
+func maxValue(frameRate SmpteFrameRate) *big.Rat {
+	return framesToAbsoluteTime(maxFrames(frameRate), frameRate)
 }
 
-//Sub Subtracts a specified TimeCode from another specified TimeCode.
-func Sub(t1, t2 *TimeCode) (*TimeCode, error) {
-	t3 := &TimeCode{
-		frameRate:    t1.frameRate,
-		absoluteTime: t1.absoluteTime.Sub(t2.absoluteTime),
-	}
+// maxFrames Gets the frame count of the last frame before 24 hours.
+func maxFrames(frameRate SmpteFrameRate) int64 {
+	return rateRecord(frameRate).hours*24 - 1
+}
 
-	if t3.TotalSeconds() < MinValue {
-		return nil, errors.New(_smpte12MMinValueOverflow)
+// MaxValue returns the last TimeCode before 24 hours at the frame rate.
+func MaxValue(rate SmpteFrameRate) (*TimeCode, error) {
+	if _, ok := lookupRate(rate); !ok {
+		return nil, fmt.Errorf(_unknownFrameRate, rate)
 	}
+	return fromAbsoluteTime(maxValue(rate), rate)
+}
 
-	return t3, nil
+//Sub Subtracts a specified TimeCode from another specified TimeCode.
+func Sub(t1, t2 *TimeCode) (*TimeCode, error) {
+	t3, err := t1.Minus(*t2)
+	if err != nil {
+		return nil, err
+	}
+	return &t3, nil
 }
 
 // NotEqual Indicates whether two TimeCode instances are not equal.
 func NotEqual(t1, t2 *TimeCode) bool {
-	var timeCode1, _ = fromTimeDecimal(t1.absoluteTime, Smpte30)
-	var timeCode2, _ = fromTimeDecimal(t2.absoluteTime, Smpte30)
-
-	if timeCode1.TotalSeconds() != timeCode2.TotalSeconds() {
-		return true
-	}
-
-	return false
+	return Compare(t1, t2) != 0
 }
 
 // Add two specified TimeCode instances.
 func Add(t1, t2 *TimeCode) (*TimeCode, error) {
-	t3 := &TimeCode{
-		frameRate:    t1.frameRate,
-		absoluteTime: t1.absoluteTime.Add(t2.absoluteTime),
-	}
-	// check overflow
-	maxValue := maxValue(t1.frameRate).Float64()
-	if t3.TotalSecondsPrecision() > maxValue && t3.TotalSeconds() > maxValue {
-		return nil, fmt.Errorf(_smpte12MMaxValueOverflow, t3.TotalSecondsPrecision(), maxValue)
+	t3, err := t1.Plus(*t2)
+	if err != nil {
+		return nil, err
 	}
-	// return
-	return t3, nil
+	return &t3, nil
 }
 
 // LessThan ..
 ///  Indicates whether a specified TimeCode is less than another
 ///  specified TimeCode.
 func LessThan(t1, t2 *TimeCode) bool {
-	var timeCode1, _ = fromTimeDecimal(t1.absoluteTime, Smpte30)
-	var timeCode2, _ = fromTimeDecimal(t2.absoluteTime, Smpte30)
-
-	if timeCode1.TotalSeconds() < timeCode2.TotalSeconds() {
-		return true
-	}
-
-	return false
+	return Compare(t1, t2) < 0
 }
 
 // LessEqual ..
@@ -813,128 +692,73 @@ func LessThan(t1, t2 *TimeCode) bool {
 /// <param name="t2">The second TimeCode.</param>
 /// <returns>true if the value of t1 is less than or equal to the value of t2; otherwise, false.</returns>
 func LessEqual(t1, t2 *TimeCode) bool {
-	var timeCode1, _ = fromTimeDecimal(t1.absoluteTime, Smpte30)
-	var timeCode2, _ = fromTimeDecimal(t2.absoluteTime, Smpte30)
-
-	if timeCode1.TotalSeconds() < timeCode2.TotalSeconds() || (timeCode1.TotalSeconds() == timeCode2.TotalSeconds()) {
-		return true
-	}
-
-	return false
+	return Compare(t1, t2) <= 0
 }
 
-//Equal  Indicates whether two TimeCode instances are equal.
+//Equal  Indicates whether two TimeCode instances are at exactly the same instant.
 func Equal(t1, t2 *TimeCode) bool {
-	var timeCode1, _ = fromTimeDecimal(t1.absoluteTime, Smpte30)
-	var timeCode2, _ = fromTimeDecimal(t2.absoluteTime, Smpte30)
-
-	if timeCode1.TotalSeconds() == timeCode2.TotalSeconds() {
-		return true
-	}
+	return Compare(t1, t2) == 0
+}
 
-	return false
+// SameLabel Indicates whether two TimeCode instances have the same frame rate and
+// fall on the same frame, so that they share the same SMPTE 12M label.
+func SameLabel(t1, t2 *TimeCode) bool {
+	return t1.Key() == t2.Key()
 }
 
 // GreatThan Indicates whether a specified TimeCode is greater than another specified
 func GreatThan(t1, t2 *TimeCode) bool {
-	var timeCode1, _ = fromTimeDecimal(t1.absoluteTime, Smpte30)
-	var timeCode2, _ = fromTimeDecimal(t2.absoluteTime, Smpte30)
-
-	if timeCode1.TotalSeconds() > timeCode2.TotalSeconds() {
-		return true
-	}
-
-	return false
+	return Compare(t1, t2) > 0
 }
 
 // GreatEqual Indicates whether a specified TimeCode is greater than or equal to
 ///     another specified TimeCode.
 func GreatEqual(t1, t2 *TimeCode) bool {
-
-	var timeCode1, _ = fromTimeDecimal(t1.absoluteTime, Smpte30)
-	var timeCode2, _ = fromTimeDecimal(t2.absoluteTime, Smpte30)
-
-	if timeCode1.TotalSeconds() > timeCode2.TotalSeconds() || (timeCode1.TotalSeconds() == timeCode2.TotalSeconds()) {
-		return true
-	}
-
-	return false
+	return Compare(t1, t2) >= 0
 }
 
 // ticks27MhzToSmpte12M Returns a SMPTE 12M formatted time code string from a 27Mhz ticks value.
 func ticks27MhzToSmpte12M(ticks27Mhz int64, rate SmpteFrameRate) string {
-	var days, hours, minutes, seconds, frames int64
-	rec := _rateRecords[rate]
-	pcrTb := float64(ticks27MhzToPcrTb(ticks27Mhz))
-	framecount := int64(pcrTb / rec.pcrTb)
-	dropFrame := false
-	// calcuate
-	days = (framecount / rec.hours) / 24
-	hours = (framecount / rec.hours) % 24
-	framecountHours := rec.hours * (hours + days*24)
-	switch rate {
-	case Smpte2997Drop:
-		minutes = ((framecount + int64(2*int32((framecount-framecountHours)/1800)) - int64(2*int32((framecount-framecountHours)/18000)) - (framecountHours)) / 1800) % 60
-		seconds = ((framecount - int64(rec.minutes*minutes) - int64(2*int32(minutes/10)) - int64(framecountHours)) / rec.frames) % 3600
-		frames = (framecount - int64(rec.frames*seconds) - (rec.minutes * minutes) - int64(2*int32(minutes/10)) - (framecountHours)) % rec.frames
-		dropFrame = true
-		break
-	case Smpte5994Drop:
-		minutes = ((framecount + int64(4*int32((framecount-framecountHours)/3600)) - int64(4*int32((framecount-framecountHours)/36000)) - (framecountHours)) / 3600) % 60
-		seconds = ((framecount - int64(rec.minutes*minutes) - int64(4*int32(minutes/10)) - int64(framecountHours)) / rec.frames) % 3600
-		frames = (framecount - int64(rec.frames*seconds) - (rec.minutes * minutes) - int64(4*int32(minutes/10)) - (framecountHours)) % rec.frames
-		dropFrame = true
-		break
-	default:
-		minutes = ((framecount - framecountHours) / rec.minutes) % 60
-		seconds = ((framecount - (rec.minutes * minutes) - (framecountHours)) / rec.frames) % 3600
-		frames = (framecount - (rec.frames * seconds) - (rec.minutes * minutes) - (framecountHours)) % rec.frames
-		break
-	}
-	return formatTimeCodeString(int32(days), int32(hours), int32(minutes), int32(seconds), int32(frames), dropFrame)
+	return absoluteTimeToSmpte12M(ticks27MhzToAbsoluteTime(ticks27Mhz), rate)
 }
 
-/*
-   /// <summary>
-   /// Compares two TimeCode values and returns an integer that indicates their relationship.
-   /// </summary>
-   /// <param name="t1">The first TimeCode.</param>
-   /// <param name="t2">The second TimeCode.</param>
-   /// <returns>
-   /// Value Condition -1 t1 is less than t2, 0 t1 is equal to t2, 1 t1 is greater than t2.
-   /// </returns>
-   public static int Compare(TimeCode t1, TimeCode t2)
-   {
-       if (t1 < t2)
-       {
-           return -1;
-       }
+// Compare ..
+/// <summary>
+/// Compares two TimeCode values and returns an integer that indicates their relationship.
+/// The absolute times are compared exactly, regardless of the frame rates.
+/// </summary>
+/// <param name="t1">The first TimeCode.</param>
+/// <param name="t2">The second TimeCode.</param>
+/// <returns>
+/// Value Condition -1 t1 is less than t2, 0 t1 is equal to t2, 1 t1 is greater than t2.
+/// </returns>
+func Compare(t1, t2 *TimeCode) int {
+	return t1.Cmp(*t2)
+}
 
-       if (t1 == t2)
-       {
-           return 0;
-       }
+// Cmp compares this instance to tc and returns -1, 0 or 1 when it is before, at or after tc.
+func (m TimeCode) Cmp(tc TimeCode) int {
+	return m.absolute().Cmp(tc.absolute())
+}
 
-       return 1;
-   }
+// Key is a comparable identity of a TimeCode label, usable as a map key.
+// Two TimeCode values have the same Key when they share the frame rate and frame.
+type Key struct {
+	FrameRate SmpteFrameRate
+	Frames    int64
+}
 
-   /// <summary>
-   ///  Returns a value indicating whether two specified instances of TimeCode
-   ///  are equal.
-   /// </summary>
-   /// <param name="t1">The first TimeCode.</param>
-   /// <param name="t2">The second TimeCode.</param>
-   /// <returns>true if the values of t1 and t2 are equal; otherwise, false.</returns>
-   public static bool Equals(TimeCode t1, TimeCode t2)
-   {
-       if (t1 == t2)
-       {
-           return true;
-       }
+// Key returns the comparable identity of this instance's label.
+func (m TimeCode) Key() Key {
+	return Key{FrameRate: m.frameRate, Frames: m.TotalFrames()}
+}
 
-       return false;
-   }
-*/
+// ByTime implements sort.Interface for []*TimeCode ordered by absolute time.
+type ByTime []*TimeCode
+
+func (a ByTime) Len() int           { return len(a) }
+func (a ByTime) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ByTime) Less(i, j int) bool { return Compare(a[i], a[j]) < 0 }
 
 // FromDays ..
 /// <summary>
@@ -952,8 +776,8 @@ func ticks27MhzToSmpte12M(ticks27Mhz int64, rate SmpteFrameRate) string {
 /// value is equal to System.Double.NaN.
 /// </exception>
 func FromDays(days float64, rate SmpteFrameRate) (*TimeCode, error) {
-	time := newDecimalFloat64(days).MulInt64(TicksPerDayAbsoluteTime)
-	return fromTimeDecimal(time, rate)
+	absoluteTime := days * float64(TicksPerDayAbsoluteTime)
+	return FromTime(absoluteTime, rate)
 }
 
 // FromHours ..
@@ -1049,8 +873,11 @@ func FromSeconds(seconds float64, rate SmpteFrameRate) (*TimeCode, error) {
 /// value is equal to System.Double.NaN.
 /// </exception>
 func FromFrames(frames int64, rate SmpteFrameRate) (*TimeCode, error) {
+	if _, ok := lookupRate(rate); !ok {
+		return nil, fmt.Errorf(_unknownFrameRate, rate)
+	}
 	time := framesToAbsoluteTime(frames, rate)
-	return fromTimeDecimal(time, rate)
+	return fromAbsoluteTime(time, rate)
 }
 
 // FromTicks27Mhz ..
@@ -1075,7 +902,7 @@ func FromFrames(frames int64, rate SmpteFrameRate) (*TimeCode, error) {
 /// <returns>A TimeCode.</returns>
 func FromTicks27Mhz(ticks27Mhz int64, rate SmpteFrameRate) (*TimeCode, error) {
 	absoluteTime := ticks27MhzToAbsoluteTime(ticks27Mhz)
-	return fromTimeDecimal(absoluteTime, rate)
+	return fromAbsoluteTime(absoluteTime, rate)
 }
 
 /*
@@ -1101,7 +928,8 @@ func FromTicks27Mhz(ticks27Mhz int64, rate SmpteFrameRate) (*TimeCode, error) {
 /// <param name="rate">The SMPTE framerate.</param>
 /// <returns>A TimeCode.</returns>
 func FromTimeSpan(span time.Duration, rate SmpteFrameRate) (*TimeCode, error) {
-	return FromTime(span.Seconds(), rate)
+	// a duration is a whole number of nanoseconds, so it is exact
+	return fromAbsoluteTime(newRat(int64(span), int64(time.Second)), rate)
 }
 
 // validateSmpte12MTimecode ..
@@ -1109,35 +937,11 @@ func FromTimeSpan(span time.Duration, rate SmpteFrameRate) (*TimeCode, error) {
 /// Validates that the string provided is in the correct format for SMPTE 12M time code.
 /// </summary>
 /// <param name="timeCode">String that is the time code.</param>
+/// <param name="rate">The SMPTE frame rate, which limits the frames of the time code.</param>
 /// <returns>True if this is a valid SMPTE 12M time code string.</returns>
-func validateSmpte12MTimecode(timeCode string) bool {
-	if !_validateTimecode.Match([]byte(timeCode)) {
-		return false
-	}
-
-	index, times := -1, regexp.MustCompile("[\\:\\;]+").Split(timeCode, -1)
-
-	days := 0
-
-	if len(times) > 4 {
-		index++
-		days, _ = strconv.Atoi(times[index])
-	}
-
-	index++
-	hours, _ := strconv.Atoi(times[index])
-	index++
-	minutes, _ := strconv.Atoi(times[index])
-	index++
-	seconds, _ := strconv.Atoi(times[index])
-	index++
-	frames, _ := strconv.Atoi(times[index])
-
-	if (days < 0) || (hours >= 24) || (minutes >= 60) || (seconds >= 60) || (frames >= 30) {
-		return false
-	}
-
-	return true
+func validateSmpte12MTimecode(timeCode string, rate SmpteFrameRate) bool {
+	_, _, _, _, _, err := parseTimecodeString(timeCode, rate)
+	return err == nil
 }
 
 /*
@@ -1170,25 +974,7 @@ func validateSmpte12MTimecode(timeCode string) bool {
 // smpte12MToTicks27Mhz Returns the value of the provided time code string and framerate in 27Mhz ticks.
 func smpte12MToTicks27Mhz(timeCode string, rate SmpteFrameRate) int64 {
 	t, _ := FromTimeCode(timeCode, rate)
-	hours, minutes, seconds, frames := t.HoursSegment(), t.MinutesSegment(), t.SecondsSegment(), t.FramesSegment()
-	days, hours, minutes, seconds, frames := t.DaysSegment(), t.HoursSegment(), t.MinutesSegment(), t.SecondsSegment(), t.FramesSegment()
-	rec := _rateRecords[rate]
-	switch rate {
-	case Smpte2997Drop:
-		ret := (3003 * frames) + (90090 * seconds) + (5399394 * minutes) + (6006 * int64(float64(seconds)/10)) + (323999676 * hours) + (7775992224 * days)
-		return int64(ret * 300)
-	case Smpte5994Drop:
-		ret := (6006 * frames) + (90090 * seconds) + (5399394 * minutes) + (12012 * int64(float64(seconds)/10)) + (323999676 * hours) + (7775992224 * days)
-		return ret * 300
-	default:
-		valFrames := rec.pcrTb
-		valSeconds := rec.pcrTb * float64(rec.frames)
-		valMinutes := valSeconds * 60
-		valHours := valMinutes * 60
-		valDays := valHours * 24
-		ret := (valFrames * float64(frames)) + (valSeconds * float64(seconds)) + (valMinutes * float64(minutes)) + (valHours * float64(hours)) + (valDays * float64(days))
-		return int64(ret * float64(300))
-	}
+	return absoluteTimeToTicks27Mhz(t.absolute())
 }
 
 // ParseFramerate ..
@@ -1198,13 +984,21 @@ func smpte12MToTicks27Mhz(timeCode string, rate SmpteFrameRate) int64 {
 /// <param name="rate">Double value of the framerate.</param>
 /// <returns>A SmpteFrameRate enumeration value that matches the incoming rates.</returns>
 func ParseFramerate(rate float64) SmpteFrameRate {
+	if math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return Unknown
+	}
+	// an exact rate first, then the lowest rate with as many frames per second
+	if ret := RateFromRational(new(big.Rat).SetFloat64(rate), false); ret != Unknown {
+		return ret
+	}
 	rateRounded := int64(math.Floor(rate))
-	for key, rec := range _rateRecords {
-		if rec.frames == rateRounded {
-			return key
+	ret := Unknown
+	for key, rec := range rateRecords() {
+		if rec.frames == rateRounded && (ret == Unknown || key < ret) {
+			ret = key
 		}
 	}
-	return Unknown
+	return ret
 }
 
 // Add ..
@@ -1218,177 +1012,170 @@ func ParseFramerate(rate float64) SmpteFrameRate {
 /// The resulting TimeCode is less than TimeCode.MinValue or greater than TimeCode.MaxValue.
 /// </exception>
 func (m *TimeCode) Add(tc *TimeCode) error {
-	m.absoluteTime.value = addBF(m.absoluteTime.value, tc.absoluteTime.value)
-	return nil
+	return m.assign(m.Plus(*tc))
 }
 
 // Sub substracts timecode
 func (m *TimeCode) Sub(tc *TimeCode) error {
-	m.absoluteTime.value = subBF(m.absoluteTime.value, tc.absoluteTime.value)
-	return nil
+	return m.assign(m.Minus(*tc))
 }
 
 // AddSeconds ..
 func (m *TimeCode) AddSeconds(seconds float64) error {
-	tc, err := FromSeconds(seconds, m.frameRate)
-	if err != nil {
-		return err
-	}
-	return m.Add(tc)
+	return m.assign(m.PlusSeconds(seconds))
 }
 
 // SubSeconds ..
 func (m *TimeCode) SubSeconds(seconds float64) error {
-	tc, err := FromSeconds(seconds, m.frameRate)
-	if err != nil {
-		return err
-	}
-	return m.Sub(tc)
+	return m.assign(m.MinusSeconds(seconds))
 }
 
 // AddFrames ..
 func (m *TimeCode) AddFrames(frames int64) error {
-	tc, err := FromFrames(frames, m.frameRate)
-	if err != nil {
-		return err
-	}
-	return m.Add(tc)
+	return m.assign(m.PlusFrames(frames))
 }
 
 // SubFrames ..
 func (m *TimeCode) SubFrames(frames int64) error {
-	tc, err := FromFrames(frames, m.frameRate)
-	if err != nil {
-		return err
-	}
-	return m.Sub(tc)
+	return m.assign(m.MinusFrames(frames))
 }
 
 // AddTimeCode ..
 func (m *TimeCode) AddTimeCode(timecode string) error {
-	tc, err := FromTimeCode(timecode, m.frameRate)
-	if err != nil {
-		return err
-	}
-	return m.Add(tc)
+	return m.assign(m.PlusTimeCode(timecode))
 }
 
 // SubTimeCode ..
 func (m *TimeCode) SubTimeCode(timecode string) error {
-	tc, err := FromTimeCode(timecode, m.frameRate)
+	return m.assign(m.MinusTimeCode(timecode))
+}
+
+// assign stores the result of a non-mutating operation in this instance.
+func (m *TimeCode) assign(tc TimeCode, err error) error {
 	if err != nil {
 		return err
 	}
-	return m.Sub(tc)
+	*m = tc
+	return nil
 }
 
-/*
-   /// <summary>
-   ///  Compares this instance to a specified object and returns an indication of
-   ///   their relative values.
-   /// </summary>
-   /// <param name="value">An object to compare, or null.</param>
-   /// <returns>
-   ///  Value Condition -1 The value of this instance is less than the value of value.
-   ///    0 The value of this instance is equal to the value of value. 1 The value
-   ///    of this instance is greater than the value of value.-or- value is null.
-   /// </returns>
-   /// <exception cref="System.ArgumentException">
-   ///  value is not a TimeCode.
-   /// </exception>
-   public int CompareTo(object value)
-   {
-       if (!(value is TimeCode))
-       {
-           throw new ArgumentException(_smpte12MOutOfRange);
-       }
-
-       TimeCode t1 = (TimeCode)value;
+// Plus returns a new TimeCode of this frame rate that is the sum of this instance and tc.
+// Results out of range are handled by the overflow policy of this instance.
+func (m TimeCode) Plus(tc TimeCode) (TimeCode, error) {
+	return m.withAbsoluteTime(new(big.Rat).Add(m.absolute(), tc.absolute()))
+}
 
-       if (this < t1)
-       {
-           return -1;
-       }
+// Minus returns a new TimeCode of this frame rate that is this instance minus tc.
+// Results out of range are handled by the overflow policy of this instance.
+func (m TimeCode) Minus(tc TimeCode) (TimeCode, error) {
+	return m.withAbsoluteTime(new(big.Rat).Sub(m.absolute(), tc.absolute()))
+}
 
-       if (this == t1)
-       {
-           return 0;
-       }
+// OverflowPolicy Gets the policy applied when arithmetic leaves the range of MinValue to MaxValue.
+func (m TimeCode) OverflowPolicy() OverflowPolicy {
+	return m.overflow
+}
 
-       return 1;
-   }
+// WithOverflowPolicy returns a copy of this instance using the overflow policy.
+func (m TimeCode) WithOverflowPolicy(policy OverflowPolicy) TimeCode {
+	m.overflow = policy
+	return m
+}
 
-   /// <summary>
-   /// Compares this instance to a specified TimeCode object and returns
-   /// an indication of their relative values.
-   /// </summary>
-   /// <param name="value"> A TimeCode object to compare to this instance.</param>
-   /// <returns>
-   /// A signed number indicating the relative values of this instance and value.Value
-   /// Description A negative integer This instance is less than value. Zero This
-   /// instance is equal to value. A positive integer This instance is greater than
-   /// value.
-   /// </returns>
-   public int CompareTo(TimeCode value)
-   {
-       if (this < value)
-       {
-           return -1;
-       }
+// SetOverflowPolicy sets the overflow policy of this instance.
+func (m *TimeCode) SetOverflowPolicy(policy OverflowPolicy) {
+	m.overflow = policy
+}
 
-       if (this == value)
-       {
-           return 0;
-       }
+// withAbsoluteTime returns a new TimeCode like this instance at the absolute time,
+// applying the overflow policy when the time is out of range.
+func (m TimeCode) withAbsoluteTime(absoluteTime *big.Rat) (TimeCode, error) {
+	ret := m
+	ret.absoluteTime = absoluteTime
+	if m.overflow == OverflowSigned {
+		if ret.Abs().TotalFrames() <= maxFrames(m.frameRate) {
+			return ret, nil
+		}
+		return m, fmt.Errorf(_smpte12MMaxValueOverflow, ret.TotalSecondsPrecision(), ratFloat64(maxValue(m.frameRate)))
+	}
+	if absoluteTime.Sign() >= 0 && ret.TotalFrames() <= maxFrames(m.frameRate) {
+		return ret, nil
+	}
+	switch m.overflow {
+	case OverflowClamp:
+		if absoluteTime.Sign() < 0 {
+			ret.absoluteTime = new(big.Rat)
+		} else {
+			ret.absoluteTime = maxValue(m.frameRate)
+		}
+	case OverflowWrap:
+		day := framesToAbsoluteTime(maxFrames(m.frameRate)+1, m.frameRate)
+		days := floorRat(new(big.Rat).Quo(absoluteTime, day))
+		ret.absoluteTime = new(big.Rat).Sub(absoluteTime, new(big.Rat).Mul(day, newRat(days, 1)))
+	default:
+		if absoluteTime.Sign() < 0 {
+			return m, errors.New(_smpte12MMinValueOverflow)
+		}
+		return m, fmt.Errorf(_smpte12MMaxValueOverflow, ret.TotalSecondsPrecision(), ratFloat64(maxValue(m.frameRate)))
+	}
+	return ret, nil
+}
 
-       return 1;
-   }
+// PlusSeconds returns a new TimeCode that is this instance plus a number of seconds.
+func (m TimeCode) PlusSeconds(seconds float64) (TimeCode, error) {
+	tc, err := FromSeconds(seconds, m.frameRate)
+	if err != nil {
+		return m, err
+	}
+	return m.Plus(*tc)
+}
 
-   /// <summary>
-   ///  Returns a value indicating whether this instance is equal to a specified
-   ///  object.
-   /// </summary>
-   /// <param name="value">An object to compare with this instance.</param>
-   /// <returns>
-   /// A true if value is a TimeCode object that represents the same time interval
-   /// as the current TimeCode structure; otherwise, false.
-   /// </returns>
-   public override bool Equals(object value)
-   {
-       if (this == (TimeCode)value)
-       {
-           return true;
-       }
+// MinusSeconds returns a new TimeCode that is this instance minus a number of seconds.
+func (m TimeCode) MinusSeconds(seconds float64) (TimeCode, error) {
+	tc, err := FromSeconds(seconds, m.frameRate)
+	if err != nil {
+		return m, err
+	}
+	return m.Minus(*tc)
+}
 
-       return false;
-   }
+// PlusFrames returns a new TimeCode that is this instance plus a number of frames.
+func (m TimeCode) PlusFrames(frames int64) (TimeCode, error) {
+	tc, err := FromFrames(frames, m.frameRate)
+	if err != nil {
+		return m, err
+	}
+	return m.Plus(*tc)
+}
 
-   /// <summary>
-   /// Returns a value indicating whether this instance is equal to a specified
-   ///     TimeCode object.
-   /// </summary>
-   /// <param name="obj">An TimeCode object to compare with this instance.</param>
-   /// <returns>true if obj represents the same time interval as this instance; otherwise, false.
-   /// </returns>
-   public bool Equals(TimeCode obj)
-   {
-       if (this == obj)
-       {
-           return true;
-       }
+// MinusFrames returns a new TimeCode that is this instance minus a number of frames.
+func (m TimeCode) MinusFrames(frames int64) (TimeCode, error) {
+	tc, err := FromFrames(frames, m.frameRate)
+	if err != nil {
+		return m, err
+	}
+	return m.Minus(*tc)
+}
 
-       return false;
-   }
+// PlusTimeCode returns a new TimeCode that is this instance plus a SMPTE 12M time code string.
+func (m TimeCode) PlusTimeCode(timecode string) (TimeCode, error) {
+	tc, err := FromTimeCode(timecode, m.frameRate)
+	if err != nil {
+		return m, err
+	}
+	return m.Plus(*tc)
+}
 
-   /// <summary>
-   /// Returns a hash code for this instance.
-   /// </summary>
-   /// <returns> A 32-bit signed integer hash code.</returns>
-   public override int GetHashCode()
-   {
-       return this.GetHashCode();
-   }
+// MinusTimeCode returns a new TimeCode that is this instance minus a SMPTE 12M time code string.
+func (m TimeCode) MinusTimeCode(timecode string) (TimeCode, error) {
+	tc, err := FromTimeCode(timecode, m.frameRate)
+	if err != nil {
+		return m, err
+	}
+	return m.Minus(*tc)
+}
 
+/*
    /// <summary>
    /// Subtracts the specified TimeCode from this instance.
    /// </summary>
@@ -1402,8 +1189,79 @@ func (m *TimeCode) SubTimeCode(timecode string) error {
 */
 
 //ToString Returns the SMPTE 12M string representation of the value of this instance.
-func (m *TimeCode) String() string {
-	return absoluteTimeToSmpte12M(m.absoluteTime, m.frameRate)
+func (m TimeCode) String() string {
+	return absoluteTimeToSmpte12M(m.absolute(), m.frameRate)
+}
+
+// signedString Returns the SMPTE 12M string of this instance with a minus sign for any
+// negative duration, including less than a frame before zero, which String shows as zero.
+func (m TimeCode) signedString() string {
+	label := m.String()
+	if m.Negative() && !strings.HasPrefix(label, "-") {
+		label = "-" + label
+	}
+	return label
+}
+
+// StringWithRate Returns the "timecode@framerate" string of this instance, which
+// FromTimeCodeRate parses back to the same value, e.g. "01:00:00;00@29.97DF".
+func (m TimeCode) StringWithRate() string {
+	return m.String() + "@" + FormatRate(m.frameRate)
+}
+
+// ConvertTo returns a new TimeCode at the frame rate, with the absolute time of this
+// instance quantised to a whole frame of the frame rate by the rounding. It also
+// returns the error of the conversion, that is the converted time minus the
+// original time, in frames of the new frame rate.
+func (m TimeCode) ConvertTo(rate SmpteFrameRate, rounding Rounding) (TimeCode, *big.Rat, error) {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return m, nil, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	frames := new(big.Rat).Mul(m.absolute(), newRat(rec.num, rec.den))
+	// quantise
+	var framecount int64
+	switch rounding {
+	case RoundNearest:
+		framecount = roundRat(frames, 0).Num().Int64()
+	case RoundCeil:
+		framecount = -floorRat(new(big.Rat).Neg(frames))
+	default:
+		framecount = floorRat(frames)
+	}
+	ret := m
+	ret.frameRate = rate
+	ret.absoluteTime = framesToAbsoluteTime(framecount, rate)
+	return ret, new(big.Rat).Sub(newRat(framecount, 1), frames), nil
+}
+
+// Reinterpret returns a new TimeCode at the frame rate with the same HH:MM:SS:FF
+// label as this instance, rather than the same absolute time, e.g. to treat the
+// 29.97 non drop label 01:00:00:00 as the drop frame label 01:00:00;00. A label
+// with a dropped frame number at the frame rate is handled by the policy.
+func (m TimeCode) Reinterpret(rate SmpteFrameRate, policy LabelPolicy) (TimeCode, error) {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return m, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	days, hours, minutes, seconds, frames := m.segments()
+	if frames >= rec.frames {
+		return m, errors.New(_smpte12MOutOfRange)
+	}
+	if dropped := droppedFrames(minutes, seconds, rate); frames < dropped {
+		if policy != LabelSnap {
+			return m, fmt.Errorf(_smpte12MDroppedFrame, m, rate)
+		}
+		frames = dropped
+	}
+	framecount := segmentsToFrames(days, hours, minutes, seconds, frames, rate)
+	if m.Negative() {
+		framecount = -framecount
+	}
+	ret := m
+	ret.frameRate = rate
+	ret.absoluteTime = framesToAbsoluteTime(framecount, rate)
+	return ret, nil
 }
 
 /*
@@ -1437,33 +1295,39 @@ func (m *TimeCode) String() string {
 */
 
 // smpte12mToAbsoluteTime Converts a SMPTE timecode to absolute time.
-func smpte12mToAbsoluteTime(timeCode string, rate SmpteFrameRate) (*decimal, error) {
-	days, hours, minutes, seconds, frames, err := parseTimecodeString(timeCode)
+func smpte12mToAbsoluteTime(timeCode string, rate SmpteFrameRate) (*big.Rat, error) {
+	// a leading minus sign is a negative duration
+	negative := strings.HasPrefix(timeCode, "-")
+	days, hours, minutes, seconds, frames, err := parseTimecodeString(strings.TrimPrefix(timeCode, "-"), rate)
 	if err != nil {
 		return nil, err
 	}
-	// get record
-	rec := _rateRecords[rate]
-	if frames >= rec.frames {
-		return nil, fmt.Errorf("Timecode frame value is not in the expected range for SMPTE %v", rec.frames)
+	ret := segmentsToFrames(days, hours, minutes, seconds, frames, rate)
+	if negative {
+		ret = -ret
 	}
-	// get time
-	ret := frames + (rec.frames * seconds) + (rec.minutes * minutes) + (rec.hours * hours) + (rec.hours * 24 * days)
-	switch rate {
-	case Smpte2398, Smpte2997NonDrop, Smpte5994NonDrop, Smpte2997Drop, Smpte5994Drop:
-		if rate == Smpte2997Drop {
-			ret += 2 * (minutes / 10)
-		} else if rate == Smpte5994Drop {
-			ret += 4 * (minutes / 10)
-		}
-		return _1001div1000.DivInt64(rec.frames).MulInt64(ret), nil
-	default:
-		return newDecimalInt64(1).DivInt64(rec.frames).MulInt64(ret), nil
+	return framesToAbsoluteTime(ret, rate), nil
+}
+
+// segmentsToFrames Converts the parts of a timecode label to a frame count.
+func segmentsToFrames(days, hours, minutes, seconds, frames int64, rate SmpteFrameRate) int64 {
+	rec := rateRecord(rate)
+	// rec.minutes excludes the dropped frame numbers, which are added back for every tenth minute
+	return frames + (rec.frames * seconds) + (rec.minutes * minutes) + (rec.drop * (minutes / 10)) + (rec.hours * hours) + (rec.hours * 24 * days)
+}
+
+// droppedFrames Returns the number of frame numbers dropped at the start of the
+// minute of a label, zero when the label is not in a dropped minute.
+func droppedFrames(minutes, seconds int64, rate SmpteFrameRate) int64 {
+	if seconds != 0 || minutes%10 == 0 {
+		return 0
 	}
+	return rateRecord(rate).drop
 }
 
-// parseTimecodeString Parses a timecode string for the different parts of the timecode.
-func parseTimecodeString(timeCode string) (days, hours, minutes, seconds, frames int64, err error) {
+// parseTimecodeString Parses a timecode string for the different parts of the timecode,
+// which must be in range at the frame rate.
+func parseTimecodeString(timeCode string, rate SmpteFrameRate) (days, hours, minutes, seconds, frames int64, err error) {
 	if !_validateTimecode.Match([]byte(timeCode)) {
 		err = errors.New(_smpte12MBadFormat)
 		return
@@ -1485,14 +1349,23 @@ func parseTimecodeString(timeCode string) (days, hours, minutes, seconds, frames
 	index++
 	frames, _ = strconv.ParseInt(times[index], 10, 64)
 
-	if (days < 0) || (hours >= 24) || (minutes >= 60) || (seconds >= 60) || (frames >= 30) {
-		err = errors.New(_smpte12MOutOfRange)
-		return
-	}
-	err = nil
+	err = validateSegments(days, hours, minutes, seconds, frames, rate)
 	return
 }
 
+// validateSegments Checks that the parts of a timecode are in range at the frame rate.
+func validateSegments(days, hours, minutes, seconds, frames int64, rate SmpteFrameRate) error {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return fmt.Errorf(_unknownFrameRate, rate)
+	}
+	if (days < 0) || (hours < 0) || (hours >= 24) || (minutes < 0) || (minutes >= 60) ||
+		(seconds < 0) || (seconds >= 60) || (frames < 0) || (frames >= rec.frames) {
+		return errors.New(_smpte12MOutOfRange)
+	}
+	return nil
+}
+
 /*
    /// <summary>
    /// Parses a timecode string for the different parts of the timecode.
@@ -1591,8 +1464,8 @@ func ticks27MhzToPcrTb(ticks27Mhz int64) int64 {
 /// </summary>
 /// <param name="ticksPcrTb">Ticks PCRTb to be converted.</param>
 /// <returns>The absolute time.</returns>
-func ticksPcrTbToAbsoluteTime(ticksPcrTb int64) *decimal {
-	return newDecimalInt64(ticksPcrTb).DivInt64(90000)
+func ticksPcrTbToAbsoluteTime(ticksPcrTb int64) *big.Rat {
+	return newRat(ticksPcrTb, 90000)
 }
 
 // ticks27MhzToAbsoluteTime ..
@@ -1601,56 +1474,61 @@ func ticksPcrTbToAbsoluteTime(ticksPcrTb int64) *decimal {
 /// </summary>
 /// <param name="ticks27Mhz">Ticks 27Mhz to be converted.</param>
 /// <returns>The absolute time.</returns>
-func ticks27MhzToAbsoluteTime(ticks27Mhz int64) *decimal {
-	ticksPcrTb := ticks27MhzToPcrTb(ticks27Mhz)
-	return ticksPcrTbToAbsoluteTime(ticksPcrTb)
+func ticks27MhzToAbsoluteTime(ticks27Mhz int64) *big.Rat {
+	return newRat(ticks27Mhz, 27000000)
+}
+
+// absoluteTimeToTicks27Mhz Converts the specified absolute time to 27 Mhz ticks.
+func absoluteTimeToTicks27Mhz(absoluteTime *big.Rat) int64 {
+	return floorRat(new(big.Rat).Mul(absoluteTime, newRat(27000000, 1)))
 }
 
 // absoluteTimeToSmpte12M Converts to SMPTE 12M.
-func absoluteTimeToSmpte12M(absoluteTime *decimal, rate SmpteFrameRate) string {
+func absoluteTimeToSmpte12M(absoluteTime *big.Rat, rate SmpteFrameRate) string {
 	framecount := absoluteTimeToFrames(absoluteTime, rate)
+	// negative durations are formatted with a leading minus sign
+	sign := ""
+	if framecount < 0 {
+		sign, framecount = "-", -framecount
+	}
+	days, hours, minutes, seconds, frames := framesToSegments(framecount, rate)
+	dropFrame := rateRecord(rate).drop > 0
+	return sign + formatTimeCodeString(int32(days), int32(hours), int32(minutes), int32(seconds), int32(frames), dropFrame)
+}
+
+// framesToSegments Splits a non-negative frame count into the segments of its SMPTE 12M label.
+func framesToSegments(framecount int64, rate SmpteFrameRate) (days, hours, minutes, seconds, frames int64) {
 	// get rate record
-	rec := _rateRecords[rate]
-	var days, hours, minutes, seconds, frames int64
-	var dropFrame = false
+	rec := rateRecord(rate)
 
 	days = (framecount / rec.hours) / 24
 	hours = (framecount / rec.hours) % 24
-	framecountHours := rec.hours * (hours + days*24)
-	if rate == Smpte2997Drop {
-		minutes = ((framecount + int64(2*int64(framecount-framecountHours)/1800) - (2 * int64(framecount-framecountHours) / 18000) - (framecountHours)) / 1800) % 60
-		seconds = ((framecount - (rec.minutes * minutes) - (2 * int64(float64(minutes)/10)) - (framecountHours)) / rec.frames) % 3600
-		frames = (framecount - (rec.frames * seconds) - (rec.minutes * minutes) - int64(2*((int)(float64(minutes)/float64(10)))) - (framecountHours)) % rec.frames
-		dropFrame = true
-	} else if rate == Smpte5994Drop {
-		minutes = ((framecount + int64(4*int64(framecount-framecountHours)/3600) - (4 * int64(framecount-framecountHours) / 3600) - (framecountHours)) / 3600) % 60
-		seconds = ((framecount - (rec.minutes * minutes) - (4 * int64(float64(minutes)/10)) - (framecountHours)) / rec.frames) % 3600
-		frames = (framecount - (rec.frames * seconds) - (rec.minutes * minutes) - int64(4*((int)(float64(minutes)/float64(10)))) - (framecountHours)) % rec.frames
-		dropFrame = true
-	} else {
-		minutes = ((framecount - framecountHours) / rec.minutes) % 60
-		seconds = ((framecount - (rec.minutes * minutes) - framecountHours) / rec.frames) % 3600
-		frames = (framecount - (rec.frames * seconds) - (rec.minutes * minutes) - framecountHours) % rec.frames
+	framecount %= rec.hours
+	if rec.drop > 0 {
+		// add back the frame numbers dropped before this frame, 9 minutes in every 10
+		tenMinutes := rec.hours / 6
+		remainder := framecount % tenMinutes
+		framecount += 9 * rec.drop * (framecount / tenMinutes)
+		if remainder > rec.drop {
+			framecount += rec.drop * ((remainder - rec.drop) / rec.minutes)
+		}
 	}
-	return formatTimeCodeString(int32(days), int32(hours), int32(minutes), int32(seconds), int32(frames), dropFrame)
+	minutes = framecount / (rec.frames * 60)
+	seconds = (framecount / rec.frames) % 60
+	frames = framecount % rec.frames
+	return
 }
 
-// absoluteTimeToFrames Returns the number of frames.
-func absoluteTimeToFrames(absoluteTime *decimal, rate SmpteFrameRate) int64 {
-	rec := _rateRecords[rate]
-	switch rate {
-	case Smpte2398, Smpte2997Drop, Smpte2997NonDrop, Smpte5994Drop, Smpte5994NonDrop:
-		ret := _1000div1001.MulInt64(rec.frames).Mul(absoluteTime.Add(_epsilon))
-		return ret.Round(4).Int64()
-		// return newDecimalFloat64(float64(ret)).Round(25).Floor().Int64()
-	default:
-		ret := absoluteTime.MulInt64(rec.frames)
-		return ret.Round(4).Int64()
-		// return absoluteTime.MulInt64(rec.frames).Round(25).Int64()
-		// ret := float32(rec.frames) * absoluteTime.Float32()
-		// return newDecimal(float64(ret)).Floor().Int64()
-		// return Convert.ToInt64(30 * (float)absoluteTime);
+// absoluteTimeToFrames Returns the number of whole frames elapsed at the absolute time.
+// Negative times are truncated towards zero, so that a negative duration is the exact
+// opposite of the positive one.
+func absoluteTimeToFrames(absoluteTime *big.Rat, rate SmpteFrameRate) int64 {
+	rec := rateRecord(rate)
+	frames := new(big.Rat).Mul(absoluteTime, newRat(rec.num, rec.den))
+	if frames.Sign() < 0 {
+		return -floorRat(frames.Neg(frames))
 	}
+	return floorRat(frames)
 }
 
 // framesToAbsoluteTime ..
@@ -1660,14 +1538,30 @@ func absoluteTimeToFrames(absoluteTime *decimal, rate SmpteFrameRate) int64 {
 /// <param name="frames">The number of frames.</param>
 /// <param name="rate">The SMPTE frame rate to use for the conversion.</param>
 /// <returns>The absolute time.</returns>
-func framesToAbsoluteTime(frames int64, rate SmpteFrameRate) *decimal {
-	rec := _rateRecords[rate]
+func framesToAbsoluteTime(frames int64, rate SmpteFrameRate) *big.Rat {
+	rec := rateRecord(rate)
+	return new(big.Rat).Mul(newRat(frames, 1), newRat(rec.den, rec.num))
+}
 
-	switch rate {
-	case Smpte2398, Smpte2997Drop, Smpte2997NonDrop, Smpte5994Drop, Smpte5994NonDrop:
-		return newDecimalInt64(frames).DivInt64(rec.frames).Div(_1000div1001)
-		// return newDecimalInt64(frames).DivInt64(rec.frames).Div(newDecimal(1000).DivFloat64(1001).Round(11))
-	default:
-		return newDecimalInt64(frames).DivInt64(rec.frames)
+// float64ToAbsoluteTime Converts a number of seconds to absolute time. A value within
+// the rounding error of a float64 of a frame boundary is snapped to the boundary.
+func float64ToAbsoluteTime(seconds float64, rate SmpteFrameRate) (*big.Rat, error) {
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return nil, errors.New(_smpte12MOutOfRange)
+	}
+	return snapAbsoluteTime(new(big.Rat).SetFloat64(seconds), rate), nil
+}
+
+// snapAbsoluteTime Returns the absolute time, or the nearest frame boundary when it is
+// within _floatTolerance of the time, for times that cannot hold a frame boundary exactly.
+func snapAbsoluteTime(absoluteTime *big.Rat, rate SmpteFrameRate) *big.Rat {
+	// get the distance to the nearest frame
+	rec := rateRecord(rate)
+	frames := new(big.Rat).Mul(absoluteTime, newRat(rec.num, rec.den))
+	nearest := framesToAbsoluteTime(roundRat(frames, 0).Num().Int64(), rate)
+	distance := new(big.Rat).Abs(new(big.Rat).Sub(absoluteTime, nearest))
+	if distance.Cmp(new(big.Rat).Mul(new(big.Rat).Abs(absoluteTime), _floatTolerance)) <= 0 {
+		return nearest
 	}
+	return absoluteTime
 }
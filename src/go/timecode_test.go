@@ -2,6 +2,8 @@ package timecode
 
 import (
 	"fmt"
+	"math/big"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -12,7 +14,7 @@ import (
 func Test_FromTimeCodeAndRate_Positive(t *testing.T) {
 	for rate, rec := range _rateRecords {
 		timeCodeRate := ""
-		if rec.drop {
+		if rec.drop > 0 {
 			timeCodeRate = fmt.Sprintf("01:02:03;04@%f", rec.rate)
 		} else {
 			timeCodeRate = fmt.Sprintf("01:02:03:04@%f", rec.rate)
@@ -28,7 +30,7 @@ func Test_FromTimeCodeAndRate_Negitive(t *testing.T) {
 	_, err := FromTimeCodeRate("01:02:03:04@12")
 	assert.NotNil(t, err, "invalid timecode and rate")
 	// invalid drop
-	_, err = FromTimeCodeRate("01:02:03;04@30")
+	_, err = FromTimeCodeRate("01:02:03;04@25")
 	assert.NotNil(t, err, "invalid timecode and rate")
 }
 
@@ -453,19 +455,19 @@ func Test_TimecodeFromTicks27BackToString2997NonDrop(t *testing.T) {
 }
 
 func Test_ValidateBadTimecode1(t *testing.T) {
-	valid := validateSmpte12MTimecode("24:00:00:12")
+	valid := validateSmpte12MTimecode("24:00:00:12", Smpte30)
 
 	assert.False(t, valid)
 }
 
 func Test_ValidateBadTimecode2(t *testing.T) {
-	valid := validateSmpte12MTimecode("01:60:10:10")
+	valid := validateSmpte12MTimecode("01:60:10:10", Smpte30)
 
 	assert.False(t, valid)
 }
 
 func Test_ValidateGoodTimecode(t *testing.T) {
-	valid := validateSmpte12MTimecode("23:38:10:10")
+	valid := validateSmpte12MTimecode("23:38:10:10", Smpte30)
 
 	assert.True(t, valid)
 }
@@ -582,7 +584,7 @@ func Test_CheckDotNetMathDivide1000By1001(t *testing.T) {
 
 func Test_CheckDotNetMathDivide30By1000By1001(t *testing.T) {
 	v, _ := strconv.ParseFloat("30.03", 64)
-	expect := newDecimalFloat64(30 / (1000 / float64(1001))).Round(2).Float64()
+	expect := ratFloat64(roundRat(new(big.Rat).SetFloat64(30/(1000/float64(1001))), 2))
 	assert.Equal(t, expect, v)
 }
 
@@ -1236,14 +1238,12 @@ func Test_KnownTimecode_MatchesString_Days(t *testing.T) {
 /// </summary>
 
 func Test_CheckSomeAbsoluteTimeToFramesAlgorithmFor2398(t *testing.T) {
-	Epsilon := newDecimalString("0.00000000000000000000000001")
-
-	absoluteTime := newDecimalString("23.481791666666666666666666648")
-	frames := newDecimalInt64(24).MulFloat64(1000).DivFloat64(1001).Mul(absoluteTime.Add(Epsilon)).Round(26).Floor().Int64()
+	absoluteTime, _ := new(big.Rat).SetString("23.481791666666666666666666648")
+	frames := absoluteTimeToFrames(absoluteTime, Smpte2398)
 	assert.Equal(t, int64(562), frames, "wrong # of frames for this absolute Time.")
 
-	absoluteTime = newDecimalFloat64(23.48179166667)
-	frames = newDecimalInt64(24).MulFloat64(1000).DivFloat64(1001).Mul(absoluteTime.Add(Epsilon)).Round(26).Floor().Int64()
+	absoluteTime, _ = new(big.Rat).SetString("23.48179166667")
+	frames = absoluteTimeToFrames(absoluteTime, Smpte2398)
 	assert.Equal(t, int64(563), frames, "wrong # of frames for this absolute Time")
 }
 
@@ -1643,7 +1643,7 @@ func Test_CreateLongRunningTimeCode_30FromIntegers(t *testing.T) {
 }
 
 func Test_ValidateBadLongRunningTimecode(t *testing.T) {
-	valid := validateSmpte12MTimecode("-1:01:00:00:12")
+	valid := validateSmpte12MTimecode("-1:01:00:00:12", Smpte30)
 
 	assert.False(t, valid)
 }
@@ -1755,3 +1755,516 @@ func Test_Constructor(t *testing.T) {
 	tc, _ = FromTicks27Mhz(300000, Smpte120)
 	assert.Equal(t, "00:00:00:01", tc.String())
 }
+
+func Test_LongRunningFramesRoundTrip(t *testing.T) {
+	for rate := range _rateRecords {
+		for _, frames := range []int64{1, 17982, 1078920, 2589407, 123456789, 987654321} {
+			tc, err := FromFrames(frames, rate)
+			assert.Nil(t, err)
+			assert.Equal(t, frames, tc.TotalFrames(), fmt.Sprintf("frames doesn't match for: '%v'", rate))
+			tc2, err := FromTimeCode(tc.String(), rate)
+			assert.Nil(t, err)
+			assert.Equal(t, frames, tc2.TotalFrames(), fmt.Sprintf("timecode '%v' doesn't match for: '%v'", tc, rate))
+			assert.Equal(t, 0, tc.AbsoluteTime().Cmp(tc2.AbsoluteTime()))
+		}
+	}
+}
+
+func Test_AbsoluteTimeIsExact(t *testing.T) {
+	tc, _ := FromFrames(1, Smpte2997NonDrop)
+	assert.Equal(t, "1001/30000", tc.AbsoluteTime().String())
+	// 27Mhz ticks are not truncated to the 90Khz clock
+	tc, _ = FromTicks27Mhz(299, Smpte30)
+	assert.Equal(t, "299/27000000", tc.AbsoluteTime().String())
+	// float64 values close to a frame boundary snap to the frame
+	tc, _ = FromSeconds(float64(1001)/30000, Smpte2997NonDrop)
+	assert.Equal(t, "1001/30000", tc.AbsoluteTime().String())
+	tc, _ = FromSeconds(float64(3600*1001)/1000, Smpte2997NonDrop)
+	assert.Equal(t, "18018/5", tc.AbsoluteTime().String())
+	// but a microsecond off a frame is not within the rounding of a float64
+	tc, _ = FromSeconds(1+1e-6, Smpte25)
+	assert.Equal(t, 0, tc.AbsoluteTime().Cmp(new(big.Rat).SetFloat64(1+1e-6)))
+	assert.Equal(t, int64(25), tc.TotalFrames())
+	tc, _ = FromSeconds(1+1e-5, Smpte25)
+	assert.Equal(t, int64(1), tc.SubFrameSamples(192000))
+	// adding one frame many times never drifts
+	tc, _ = FromFrames(0, Smpte5994NonDrop)
+	for i := 0; i < 100000; i++ {
+		tc.AddFrames(1)
+	}
+	assert.Equal(t, "5005/3", tc.AbsoluteTime().String())
+	assert.Equal(t, int64(100000), tc.TotalFrames())
+}
+
+func Test_FromTimeSpanIsExact(t *testing.T) {
+	tc, err := FromTimeSpan(1500*time.Millisecond, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "3/2", tc.AbsoluteTime().RatString())
+	tc, _ = FromTimeSpan(time.Hour+time.Millisecond, Smpte25)
+	assert.Equal(t, "3600001/1000", tc.AbsoluteTime().RatString())
+	// an offset of a few microseconds from a frame is kept
+	tc, _ = FromTimeSpan(time.Second+3*time.Microsecond, Smpte25)
+	assert.Equal(t, "1000003/1000000", tc.AbsoluteTime().RatString())
+	assert.Equal(t, int64(25), tc.TotalFrames())
+	_, err = FromTimeSpan(time.Second, Unknown)
+	assert.NotNil(t, err)
+}
+
+func Test_ConstructorsRejectUnknownRate(t *testing.T) {
+	tests := []struct {
+		name string
+		from func(rate SmpteFrameRate) error
+	}{
+		{"FromFrames", func(rate SmpteFrameRate) error { _, err := FromFrames(1, rate); return err }},
+		{"FromTime", func(rate SmpteFrameRate) error { _, err := FromTime(1, rate); return err }},
+		{"FromSeconds", func(rate SmpteFrameRate) error { _, err := FromSeconds(1, rate); return err }},
+		{"FromDays", func(rate SmpteFrameRate) error { _, err := FromDays(1, rate); return err }},
+		{"FromTicks27Mhz", func(rate SmpteFrameRate) error { _, err := FromTicks27Mhz(27000000, rate); return err }},
+		{"FromTimeSpan", func(rate SmpteFrameRate) error { _, err := FromTimeSpan(time.Second, rate); return err }},
+		{"FromTimeCode", func(rate SmpteFrameRate) error { _, err := FromTimeCode("00:00:01:00", rate); return err }},
+		{"FromTimeHours", func(rate SmpteFrameRate) error { _, err := FromTimeHours(0, 0, 1, 0, rate); return err }},
+		{"FromFieldTimeCode", func(rate SmpteFrameRate) error { _, err := FromFieldTimeCode("00:00:01:00.1", rate); return err }},
+		{"FromSubFrameTimeCode", func(rate SmpteFrameRate) error {
+			_, err := FromSubFrameTimeCode("00:00:01:00.10", SubFrameBits, rate)
+			return err
+		}},
+		{"FromFeetFrames", func(rate SmpteFrameRate) error { _, err := FromFeetFrames("1+00", Film35mm4Perf, rate); return err }},
+	}
+	for _, test := range tests {
+		for _, rate := range []SmpteFrameRate{Unknown, SmpteFrameRate(999)} {
+			assert.NotNil(t, test.from(rate), fmt.Sprintf("%v at %v", test.name, rate))
+		}
+	}
+}
+
+func Test_ParseFramerate(t *testing.T) {
+	tests := []struct {
+		rate     float64
+		expected SmpteFrameRate
+	}{
+		{24, Smpte24},
+		{24.5, Smpte2398},
+		{25, Smpte25},
+		{30, Smpte30},
+		{30.5, Smpte2997Drop},
+		{29.97, Unknown},
+		{96, Smpte96},
+		{95.9, Unknown},
+		{120, Smpte120},
+		{120.5, Smpte120},
+		{12, Unknown},
+	}
+	for i := 0; i < 50; i++ {
+		for _, test := range tests {
+			assert.Equal(t, test.expected, ParseFramerate(test.rate), fmt.Sprint(test.rate))
+		}
+	}
+}
+
+func Test_ZeroValueTimeCode(t *testing.T) {
+	var tc TimeCode
+	assert.Equal(t, "00:00:00:00", tc.String())
+	assert.Equal(t, int64(0), tc.TotalFrames())
+	tc, err := tc.PlusFrames(25)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:01:01", tc.String())
+}
+
+func Test_PlusMinusDoNotMutate(t *testing.T) {
+	t1, _ := FromTimeCode("01:00:00;00", Smpte2997Drop)
+	t2, _ := FromTimeCode("00:00:10;00", Smpte2997Drop)
+	t3, err := t1.Plus(*t2)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:10;00", t3.String())
+	assert.Equal(t, "01:00:00;00", t1.String())
+	t4, err := t3.Minus(*t2)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;00", t4.String())
+	assert.Equal(t, "01:00:10;00", t3.String())
+	// frames, seconds and strings
+	t5, _ := t1.PlusFrames(2)
+	assert.Equal(t, "01:00:00;02", t5.String())
+	t5, _ = t5.MinusFrames(3)
+	assert.Equal(t, "00:59:59;29", t5.String())
+	t5, _ = t1.PlusSeconds(1.001)
+	assert.Equal(t, "01:00:01;00", t5.String())
+	t5, _ = t5.MinusSeconds(1.001)
+	assert.Equal(t, "01:00:00;00", t5.String())
+	t5, _ = t1.PlusTimeCode("00:01:00;02")
+	assert.Equal(t, "01:01:00;02", t5.String())
+	t5, _ = t5.MinusTimeCode("00:01:00;02")
+	assert.Equal(t, "01:00:00;00", t5.String())
+	_, err = t1.PlusTimeCode("bad")
+	assert.NotNil(t, err)
+	assert.Equal(t, "01:00:00;00", t1.String())
+}
+
+func Test_CopiesDoNotShareState(t *testing.T) {
+	t1, _ := FromTimeCode("00:00:01:00", Smpte25)
+	t2 := *t1
+	t3, _ := Add(t1, t1)
+	t1.AddFrames(1)
+	assert.Equal(t, "00:00:01:01", t1.String())
+	assert.Equal(t, "00:00:01:00", t2.String())
+	assert.Equal(t, "00:00:02:00", t3.String())
+	// changing the exact time returned does not change the timecode
+	t2.AbsoluteTime().SetInt64(100)
+	assert.Equal(t, "00:00:01:00", t2.String())
+}
+
+func Test_ShareAcrossGoroutines(t *testing.T) {
+	tc, _ := FromTimeCode("00:10:00;00", Smpte2997Drop)
+	shared := *tc
+	done := make(chan string)
+	for i := 0; i < 8; i++ {
+		go func(n int64) {
+			v, _ := shared.PlusFrames(n)
+			v, _ = v.MinusFrames(n)
+			done <- v.String()
+		}(int64(i))
+	}
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, "00:10:00;00", <-done)
+	}
+}
+
+func Test_CompareDistinct5994Frames(t *testing.T) {
+	t1, _ := FromFrames(5184000*3+1, Smpte5994NonDrop)
+	t2, _ := FromFrames(5184000*3+2, Smpte5994NonDrop)
+	assert.Equal(t, -1, Compare(t1, t2))
+	assert.Equal(t, 1, Compare(t2, t1))
+	assert.Equal(t, 0, Compare(t1, t1))
+	assert.Equal(t, -1, t1.Cmp(*t2))
+	assert.False(t, Equal(t1, t2))
+	assert.True(t, NotEqual(t1, t2))
+	assert.True(t, LessThan(t1, t2))
+	assert.True(t, GreatThan(t2, t1))
+}
+
+func Test_EqualInstantAndSameLabel(t *testing.T) {
+	// same instant, different labels
+	t1, _ := FromTimeCode("00:12:33;26", Smpte2997Drop)
+	t2, _ := FromTimeCode("00:12:33:04", Smpte2997NonDrop)
+	assert.True(t, Equal(t1, t2))
+	assert.False(t, SameLabel(t1, t2))
+	// same label, different instants inside the frame
+	t3, _ := FromTime(1.01, Smpte25)
+	t4, _ := FromTime(1.02, Smpte25)
+	assert.False(t, Equal(t3, t4))
+	assert.True(t, SameLabel(t3, t4))
+	assert.Equal(t, t3.String(), t4.String())
+	// 1 second at 30 fps and 24 fps is the same instant
+	t5, _ := FromTimeCode("00:00:01:00", Smpte30)
+	t6, _ := FromTimeCode("00:00:01:00", Smpte24)
+	assert.True(t, Equal(t5, t6))
+	assert.False(t, SameLabel(t5, t6))
+}
+
+func Test_SortByTime(t *testing.T) {
+	t1, _ := FromTimeCode("00:00:01:00", Smpte25)
+	t2, _ := FromTimeCode("00:00:00:29", Smpte30)
+	t3, _ := FromTimeCode("00:00:00;29", Smpte2997Drop)
+	t4, _ := FromTimeCode("00:00:00:00", Smpte24)
+	tcs := []*TimeCode{t1, t2, t3, t4}
+	sort.Sort(ByTime(tcs))
+	assert.Equal(t, []*TimeCode{t4, t2, t3, t1}, tcs)
+}
+
+func Test_KeyAsMapKey(t *testing.T) {
+	t1, _ := FromTime(1.01, Smpte25)
+	t2, _ := FromTime(1.02, Smpte25)
+	t3, _ := FromTimeCode("00:00:01:00", Smpte24)
+	seen := map[Key]int{}
+	for _, tc := range []*TimeCode{t1, t2, t3} {
+		seen[tc.Key()]++
+	}
+	assert.Equal(t, 2, len(seen))
+	assert.Equal(t, 2, seen[Key{FrameRate: Smpte25, Frames: 25}])
+	assert.Equal(t, 1, seen[t3.Key()])
+}
+
+func Test_OverflowPolicyError(t *testing.T) {
+	tc, _ := FromTimeCode("23:59:59:24", Smpte25)
+	assert.Equal(t, OverflowError, tc.OverflowPolicy())
+	assert.NotNil(t, tc.AddFrames(1))
+	assert.Equal(t, "23:59:59:24", tc.String())
+	assert.NotNil(t, tc.AddSeconds(1))
+	assert.NotNil(t, tc.AddTimeCode("00:00:00:01"))
+	tc, _ = FromTimeCode("00:00:00:01", Smpte25)
+	assert.NotNil(t, tc.SubFrames(2))
+	assert.NotNil(t, tc.SubSeconds(1))
+	assert.NotNil(t, tc.SubTimeCode("00:00:01:00"))
+	assert.Equal(t, "00:00:00:01", tc.String())
+	_, err := tc.MinusFrames(2)
+	assert.NotNil(t, err)
+	assert.Nil(t, tc.SubFrames(1))
+	assert.Equal(t, "00:00:00:00", tc.String())
+}
+
+func Test_OverflowPolicyClamp(t *testing.T) {
+	tc, _ := FromTimeCode("23:59:59;28", Smpte2997Drop)
+	tc.SetOverflowPolicy(OverflowClamp)
+	assert.Nil(t, tc.AddFrames(10))
+	assert.Equal(t, "23:59:59;29", tc.String())
+	assert.Nil(t, tc.SubTimeCode("23:59:59;29"))
+	assert.Equal(t, "00:00:00;00", tc.String())
+	assert.Nil(t, tc.SubFrames(1))
+	assert.Equal(t, "00:00:00;00", tc.String())
+	// package level functions use the policy of the first timecode
+	t1, _ := FromTimeHours(12, 01, 00, 00, Smpte30)
+	t2, _ := FromTimeHours(12, 01, 00, 22, Smpte30)
+	clamped := t1.WithOverflowPolicy(OverflowClamp)
+	t3, err := Add(&clamped, t2)
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59:29", t3.String())
+	t3, err = Sub(t2, &clamped)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:22", t3.String())
+	t3, err = Sub(&clamped, t2)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:00", t3.String())
+}
+
+func Test_OverflowPolicyWrap(t *testing.T) {
+	for rate := range _rateRecords {
+		tc, _ := FromFrames(maxFrames(rate), rate)
+		v := tc.WithOverflowPolicy(OverflowWrap)
+		v, err := v.PlusFrames(3)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), v.TotalFrames(), fmt.Sprintf("invalid wrap for: '%v'", rate))
+		v, err = v.MinusFrames(5)
+		assert.Nil(t, err)
+		assert.Equal(t, maxFrames(rate)-2, v.TotalFrames(), fmt.Sprintf("invalid wrap for: '%v'", rate))
+	}
+	tc, _ := FromTimeCode("22:00:00;00", Smpte2997Drop)
+	tc.SetOverflowPolicy(OverflowWrap)
+	assert.Nil(t, tc.AddTimeCode("03:00:00;02"))
+	assert.Equal(t, "01:00:00;02", tc.String())
+	assert.Nil(t, tc.SubTimeCode("01:00:00;04"))
+	assert.Equal(t, "23:59:59;28", tc.String())
+}
+
+func Test_NegativeTimeCodeParseAndFormat(t *testing.T) {
+	tc, err := FromTimeCode("-00:00:01:05", Smpte25)
+	assert.Nil(t, err)
+	assert.True(t, tc.Negative())
+	assert.Equal(t, "-00:00:01:05", tc.String())
+	assert.Equal(t, int64(-30), tc.TotalFrames())
+	assert.Equal(t, float64(-1.2), tc.TotalSeconds())
+	assert.Equal(t, int64(1), tc.SecondsSegment())
+	assert.Equal(t, int64(5), tc.FramesSegment())
+	assert.Equal(t, "00:00:01:05", tc.Abs().String())
+	assert.Equal(t, "00:00:01:05", tc.Neg().String())
+	// drop frame and days
+	tc, _ = FromTimeCode("-00:01:00;02", Smpte2997Drop)
+	assert.Equal(t, "-00:01:00;02", tc.String())
+	assert.Equal(t, int64(-1800), tc.TotalFrames())
+	tc, _ = FromTimeCode("-1:01:00:00:12", Smpte24)
+	assert.Equal(t, "-01:01:00:00:12", tc.String())
+	assert.Equal(t, int64(1), tc.DaysSegment())
+	// from negative frames and seconds
+	tc, _ = FromFrames(-3, Smpte2398)
+	assert.Equal(t, "-00:00:00:03", tc.String())
+	tc, _ = FromSeconds(-1.5, Smpte30)
+	assert.Equal(t, "-00:00:01:15", tc.String())
+	// only one minus sign, and nothing around the label
+	for _, timeCode := range []string{"--00:00:01:05", "-+00:00:01:05", "- 00:00:01:05", "x00:00:01:05", "00:00:01:05x", "00:-00:01:05"} {
+		_, err = FromTimeCode(timeCode, Smpte25)
+		assert.NotNil(t, err, timeCode)
+	}
+	_, err = FromSubFrameTimeCode("--00:00:01:05.10", SubFrameBits, Smpte25)
+	assert.NotNil(t, err)
+}
+
+func Test_SignedSubAndAdd(t *testing.T) {
+	picture, _ := FromTimeCode("01:00:00:00", Smpte2398)
+	audio, _ := FromTimeCode("00:59:59:21", Smpte2398)
+	// unsigned by default
+	_, err := Sub(audio, picture)
+	assert.NotNil(t, err)
+	// signed when asked
+	signed := audio.WithOverflowPolicy(OverflowSigned)
+	delta, err := Sub(&signed, picture)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00:03", delta.String())
+	assert.True(t, delta.Negative())
+	assert.Equal(t, OverflowSigned, delta.OverflowPolicy())
+	// adding a negative offset
+	offset, _ := FromTimeCode("-00:00:00:03", Smpte2398)
+	tc, err := Add(picture, offset)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:59:59:21", tc.String())
+	v, err := delta.Plus(*offset)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:00:00:06", v.String())
+	v, err = v.PlusFrames(8)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:02", v.String())
+	// still bounded by MaxValue
+	_, err = delta.MinusTimeCode("23:59:59:23")
+	assert.NotNil(t, err)
+}
+
+func Test_ConvertTo(t *testing.T) {
+	// 23.98 source in a 25 fps timeline
+	tc, _ := FromTimeCode("00:00:01:01", Smpte2398)
+	floor, diff, err := tc.ConvertTo(Smpte25, RoundFloor)
+	assert.Nil(t, err)
+	assert.Equal(t, Smpte25, floor.FrameRate())
+	assert.Equal(t, "00:00:01:01", floor.String())
+	assert.Equal(t, "-13/192", diff.RatString())
+	nearest, diff, _ := tc.ConvertTo(Smpte25, RoundNearest)
+	assert.Equal(t, "00:00:01:01", nearest.String())
+	assert.Equal(t, "-13/192", diff.RatString())
+	ceil, diff, _ := tc.ConvertTo(Smpte25, RoundCeil)
+	assert.Equal(t, "00:00:01:02", ceil.String())
+	assert.Equal(t, "179/192", diff.RatString())
+	// 00:00:01:12 is 37.54 frames at 25 fps
+	tc, _ = FromTimeCode("00:00:01:12", Smpte2398)
+	floor, _, _ = tc.ConvertTo(Smpte25, RoundFloor)
+	assert.Equal(t, "00:00:01:12", floor.String())
+	nearest, _, _ = tc.ConvertTo(Smpte25, RoundNearest)
+	assert.Equal(t, "00:00:01:13", nearest.String())
+	// an exact frame converts without error
+	tc, _ = FromTimeCode("01:00:00:00", Smpte24)
+	for _, rounding := range []Rounding{RoundFloor, RoundNearest, RoundCeil} {
+		v, diff, _ := tc.ConvertTo(Smpte25, rounding)
+		assert.Equal(t, "01:00:00:00", v.String())
+		assert.Equal(t, 0, diff.Sign())
+	}
+	// every rate converts to every other rate within a frame
+	tc, _ = FromTimeCode("10:11:12;13", Smpte2997Drop)
+	for rate := range _rateRecords {
+		for _, rounding := range []Rounding{RoundFloor, RoundNearest, RoundCeil} {
+			v, diff, err := tc.ConvertTo(rate, rounding)
+			assert.Nil(t, err)
+			assert.Equal(t, rate, v.FrameRate())
+			assert.True(t, new(big.Rat).Abs(diff).Cmp(big.NewRat(1, 1)) < 0)
+			back := new(big.Rat).Sub(v.AbsoluteTime(), tc.AbsoluteTime())
+			rec := _rateRecords[rate]
+			assert.Equal(t, 0, back.Mul(back, big.NewRat(rec.num, rec.den)).Cmp(diff))
+		}
+	}
+	// unknown rate
+	_, _, err = tc.ConvertTo(Unknown, RoundFloor)
+	assert.NotNil(t, err)
+}
+
+func Test_Reinterpret(t *testing.T) {
+	// NDF label as the DF label
+	ndf, _ := FromTimeCode("01:00:00:00", Smpte2997NonDrop)
+	df, err := Reinterpret(ndf, Smpte2997Drop, LabelError)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00;00", df.String())
+	assert.Equal(t, int64(107892), df.TotalFrames())
+	// and back again
+	back, err := df.Reinterpret(Smpte2997NonDrop, LabelError)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00", back.String())
+	assert.Equal(t, int64(108000), back.TotalFrames())
+	// 59.94
+	ndf, _ = FromTimeCode("00:10:00:00", Smpte5994NonDrop)
+	v, err := ndf.Reinterpret(Smpte5994Drop, LabelError)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(35964), v.TotalFrames())
+	assert.Equal(t, "00:10:00;00", v.String())
+	// dropped frame numbers
+	ndf, _ = FromTimeCode("00:01:00:01", Smpte2997NonDrop)
+	_, err = ndf.Reinterpret(Smpte2997Drop, LabelError)
+	assert.NotNil(t, err)
+	v, err = ndf.Reinterpret(Smpte2997Drop, LabelSnap)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:01:00;02", v.String())
+	ndf, _ = FromTimeCode("00:02:00:03", Smpte5994NonDrop)
+	_, err = ndf.Reinterpret(Smpte5994Drop, LabelError)
+	assert.NotNil(t, err)
+	v, _ = ndf.Reinterpret(Smpte5994Drop, LabelSnap)
+	assert.Equal(t, int64(2*3596+4), v.TotalFrames())
+	assert.Equal(t, "00:02:00;04", v.String())
+	// frame number out of range at the frame rate
+	ndf, _ = FromTimeCode("00:00:00:29", Smpte2997NonDrop)
+	_, err = ndf.Reinterpret(Smpte25, LabelSnap)
+	assert.NotNil(t, err)
+	// negative durations keep the sign
+	neg, _ := FromTimeCode("-00:01:00:05", Smpte2997NonDrop)
+	v, err = neg.Reinterpret(Smpte2997Drop, LabelError)
+	assert.Nil(t, err)
+	assert.Equal(t, "-00:01:00;05", v.String())
+}
+
+func Test_DropFrameLabelsRoundTrip(t *testing.T) {
+	for rate, rec := range _rateRecords {
+		if rec.drop == 0 {
+			continue
+		}
+		// two ten minute blocks from the start, and across the turn of the hour
+		tenMinutes := rec.hours / 6
+		for _, start := range []int64{0, rec.hours - tenMinutes} {
+			for framecount := start; framecount < start+2*tenMinutes; framecount++ {
+				days, hours, minutes, seconds, frames := framesToSegments(framecount, rate)
+				assert.True(t, frames >= droppedFrames(minutes, seconds, rate), "dropped label for frame %v", framecount)
+				assert.Equal(t, framecount, segmentsToFrames(days, hours, minutes, seconds, frames, rate))
+			}
+		}
+	}
+}
+
+func Test_DropFrame11988(t *testing.T) {
+	tc, err := FromFrames(7199, Smpte11988Drop)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:59;119", tc.String())
+	tc, _ = FromFrames(7200, Smpte11988Drop)
+	assert.Equal(t, "00:01:00;08", tc.String())
+	tc, _ = FromFrames(7200+9*7192, Smpte11988Drop)
+	assert.Equal(t, "00:10:00;00", tc.String())
+	tc, _ = FromFrames(431568, Smpte11988Drop)
+	assert.Equal(t, "01:00:00;00", tc.String())
+	assert.Equal(t, int64(1), tc.HoursSegment())
+	// an hour of drop frame labels is an hour of real time, within 4 ms
+	assert.InDelta(t, 3600, tc.TotalSeconds(), 0.004)
+}
+
+func Test_HighFrameRateLabels(t *testing.T) {
+	tc, err := FromTimeCode("00:00:00:45", Smpte50)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(45), tc.TotalFrames())
+	assert.Equal(t, "00:00:00:45", tc.String())
+	tc, err = FromTimeCode("00:00:01:59", Smpte60)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(119), tc.TotalFrames())
+	tc, err = FromTimeCode("00:00:00:110", Smpte120)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(110), tc.TotalFrames())
+	assert.Equal(t, "00:00:00:110", tc.String())
+	assert.Equal(t, int64(110), tc.FramesSegment())
+	tc, err = FromTimeCode("00:01:00;08", Smpte11988Drop)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7200), tc.TotalFrames())
+	tc, err = FromTimeCode("01:00:00:99", Smpte100)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:99", tc.String())
+	// the frame limit comes from the rate
+	_, err = FromTimeCode("00:00:00:25", Smpte25)
+	assert.NotNil(t, err)
+	_, err = FromTimeCode("00:00:00:120", Smpte120)
+	assert.NotNil(t, err)
+	_, err = FromTimeCode("00:00:00:60", Smpte5994Drop)
+	assert.NotNil(t, err)
+	assert.True(t, validateSmpte12MTimecode("00:00:00:45", Smpte50))
+	assert.False(t, validateSmpte12MTimecode("00:00:00:45", Smpte2997NonDrop))
+	assert.True(t, validateSmpte12MTimecode("00:00:00:119", Smpte120))
+	assert.False(t, validateSmpte12MTimecode("00:00:00:10", Unknown))
+	// FromTimeHours and FromTimeDays use the same limits
+	tc, err = FromTimeHours(0, 0, 0, 110, Smpte120)
+	assert.Nil(t, err)
+	assert.Equal(t, "00:00:00:110", tc.String())
+	tc, err = FromTimeDays(1, 0, 0, 0, 59, Smpte5994NonDrop)
+	assert.Nil(t, err)
+	assert.Equal(t, "01:00:00:00:59", tc.String())
+	_, err = FromTimeHours(0, 0, 0, 24, Smpte24)
+	assert.NotNil(t, err)
+	_, err = FromTimeHours(0, -1, 0, 0, Smpte24)
+	assert.NotNil(t, err)
+	_, err = FromTimeHours(100, 0, 0, 0, Smpte24)
+	assert.NotNil(t, err)
+}
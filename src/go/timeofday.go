@@ -0,0 +1,85 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// FromTimeOfDay Initializes a new instance of the TimeCode struct with the time of day
+// of the wall clock time in its time zone, as used to stamp live recordings. Use t.In to
+// take the time of day in another time zone. The label follows the wall clock, so it
+// jumps with daylight saving time. A drop frame label reads the wall clock, so its
+// absolute time differs a little from the time since midnight, and in the first frames
+// of a minute that are dropped the label is the next one that exists.
+func FromTimeOfDay(t time.Time, rate SmpteFrameRate) (*TimeCode, error) {
+	rec, ok := lookupRate(rate)
+	if !ok {
+		return nil, fmt.Errorf(_unknownFrameRate, rate)
+	}
+	hours, minutes, seconds := t.Clock()
+	// the frame of the label and the fraction of the frame, at the nominal rate
+	nominal := int64(t.Nanosecond()) * rec.frames
+	frames, remainder := nominal/int64(time.Second), newRat(nominal%int64(time.Second), int64(time.Second))
+	if dropped := droppedFrames(int64(minutes), int64(seconds), rate); frames < dropped {
+		frames, remainder = dropped, new(big.Rat)
+	}
+	framecount := segmentsToFrames(0, int64(hours), int64(minutes), int64(seconds), frames, rate)
+	absoluteTime := new(big.Rat).Add(newRat(framecount, 1), remainder)
+	return fromAbsoluteTime(absoluteTime.Mul(absoluteTime, newRat(rec.den, rec.num)), rate)
+}
+
+// timeOfDay Returns the wall clock time of the label of this instance, days after
+// midnight of the calendar date in the time zone.
+func (m TimeCode) timeOfDay(year int, month time.Month, day int, loc *time.Location) (time.Time, error) {
+	if m.Negative() {
+		return time.Time{}, errors.New(_smpte12MMinValueOverflow)
+	}
+	if loc == nil {
+		return time.Time{}, errors.New("timecode: missing time zone")
+	}
+	rec := rateRecord(m.frameRate)
+	days, hours, minutes, seconds, frames := m.segments()
+	// frames of the label at the nominal rate, with the sub-frame
+	nominal := new(big.Rat).Add(newRat(frames, 1), m.SubFrame())
+	nanoseconds := floorRat(nominal.Mul(nominal, newRat(int64(time.Second), rec.frames)))
+	return time.Date(year, month, day+int(days), int(hours), int(minutes), int(seconds), int(nanoseconds), loc), nil
+}
+
+// TimeOfDay Returns the wall clock time of the label of this instance on the calendar date
+// of date, in the time zone. A label of more than 24 hours rolls over onto the following
+// days. A wall clock time that is skipped or repeated by a daylight saving time transition
+// is resolved like time.Date.
+func (m TimeCode) TimeOfDay(date time.Time, loc *time.Location) (time.Time, error) {
+	year, month, day := date.Date()
+	return m.timeOfDay(year, month, day, loc)
+}
+
+// TimeOfDayNear Returns the wall clock time of the time of day label of this instance
+// nearest to the reference time, in its time zone, which handles the midnight rollover
+// of a recording: a label of 00:05:00:00 near 23:50 is on the next day.
+func (m TimeCode) TimeOfDayNear(ref time.Time) (time.Time, error) {
+	year, month, day := ref.Date()
+	// only the time of day of the label is used
+	day -= int(m.DaysSegment())
+	var ret time.Time
+	for offset := -1; offset <= 1; offset++ {
+		t, err := m.timeOfDay(year, month, day+offset, ref.Location())
+		if err != nil {
+			return t, err
+		}
+		if offset == -1 || absDuration(t.Sub(ref)) < absDuration(ret.Sub(ref)) {
+			ret = t
+		}
+	}
+	return ret, nil
+}
+
+// absDuration Returns the absolute value of a duration.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
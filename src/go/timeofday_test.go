@@ -0,0 +1,91 @@
+package timecode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromTimeOfDay(t *testing.T) {
+	wall := time.Date(2026, 10, 17, 10, 20, 30, 500000000, time.UTC)
+	tc, err := FromTimeOfDay(wall, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "10:20:30:12", tc.String())
+	assert.Equal(t, "1/2", tc.SubFrame().RatString())
+	// drop frame labels read the wall clock, not the time since midnight
+	tc, _ = FromTimeOfDay(time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC), Smpte2997Drop)
+	assert.Equal(t, "10:00:00;00", tc.String())
+	assert.Equal(t, "35999.964", tc.AbsoluteTime().FloatString(3))
+	// the first frames of a minute are dropped
+	tc, _ = FromTimeOfDay(time.Date(2026, 10, 17, 10, 1, 0, 10000000, time.UTC), Smpte2997Drop)
+	assert.Equal(t, "10:01:00;02", tc.String())
+	assert.Equal(t, 0, tc.SubFrame().Sign())
+	// the time zone of the time
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	tc, _ = FromTimeOfDay(wall.In(zone), Smpte24)
+	assert.Equal(t, "12:20:30:12", tc.String())
+	_, err = FromTimeOfDay(wall, Unknown)
+	assert.NotNil(t, err)
+}
+
+func Test_TimeOfDay(t *testing.T) {
+	zone := time.FixedZone("UTC-5", -5*60*60)
+	wall := time.Date(2026, 10, 17, 23, 59, 59, 123456789, zone)
+	for _, rate := range []SmpteFrameRate{Smpte2398, Smpte25, Smpte2997Drop, Smpte2997NonDrop, Smpte5994Drop, Smpte120} {
+		tc, _ := FromTimeOfDay(wall, rate)
+		back, err := tc.TimeOfDay(wall, zone)
+		assert.Nil(t, err)
+		assert.True(t, wall.Equal(back), "%v %v", rate, back)
+	}
+	// rolls over onto the next day
+	tc, _ := FromTimeCode("01:00:00:00:00", Smpte25)
+	back, _ := tc.TimeOfDay(wall, zone)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, zone), back)
+	// errors
+	neg, _ := FromTimeCode("-00:00:01:00", Smpte25)
+	_, err := neg.TimeOfDay(wall, zone)
+	assert.NotNil(t, err)
+	_, err = tc.TimeOfDay(wall, nil)
+	assert.NotNil(t, err)
+}
+
+func Test_TimeOfDayNear(t *testing.T) {
+	ref := time.Date(2026, 10, 17, 23, 50, 0, 0, time.UTC)
+	// after midnight
+	tc, _ := FromTimeCode("00:05:00:00", Smpte25)
+	v, err := tc.TimeOfDayNear(ref)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 5, 0, 0, time.UTC), v)
+	// before midnight
+	tc, _ = FromTimeCode("23:55:00:00", Smpte25)
+	v, _ = tc.TimeOfDayNear(time.Date(2026, 10, 18, 0, 2, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 10, 17, 23, 55, 0, 0, time.UTC), v)
+	// the same day, and the days of a label are ignored
+	tc, _ = FromTimeCode("02:12:00:00:00", Smpte25)
+	v, _ = tc.TimeOfDayNear(ref)
+	assert.Equal(t, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), v)
+}
+
+func Test_TimeOfDayDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	// clocks go forward from 02:00 to 03:00 on 8 March 2026
+	before := time.Date(2026, 3, 8, 1, 59, 59, 0, loc)
+	after := before.Add(time.Second)
+	tc, _ := FromTimeOfDay(before, Smpte30)
+	assert.Equal(t, "01:59:59:00", tc.String())
+	tc, _ = FromTimeOfDay(after, Smpte30)
+	assert.Equal(t, "03:00:00:00", tc.String())
+	v, _ := tc.TimeOfDay(after, loc)
+	assert.True(t, after.Equal(v))
+	// and back from 02:00 to 01:00 on 1 November 2026, 01:30 is labelled twice
+	first := time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC).In(loc)
+	second := first.Add(time.Hour)
+	tc1, _ := FromTimeOfDay(first, Smpte30)
+	tc2, _ := FromTimeOfDay(second, Smpte30)
+	assert.Equal(t, "01:30:00:00", tc1.String())
+	assert.Equal(t, "01:30:00:00", tc2.String())
+}
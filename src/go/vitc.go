@@ -0,0 +1,175 @@
+package timecode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// _vitcBits is the number of bits of a SMPTE ST 12-1 vertical interval timecode word.
+const _vitcBits = 90
+
+// vitcBit Returns the position in a VITC word of bit n of the first 64 bits of a LTC
+// frame. Each group of 8 bits follows a sync bit pair of 1 then 0.
+func vitcBit(n uint) uint {
+	return 2 + n + 2*(n/8)
+}
+
+// vitcCRC Returns the CRC of the first 82 bits of a VITC word, the polynomial x^8 + 1,
+// so bit n of the CRC is the exclusive or of every eighth bit from bit n of the word.
+func vitcCRC(bits []byte) [8]byte {
+	var ret [8]byte
+	for i := 0; i < 82; i++ {
+		ret[(i+6)%8] ^= bits[i] & 1
+	}
+	return ret
+}
+
+// EncodeVITC Encodes the timecode and user bits as a VITC word, with no flags set besides
+// drop frame and the field mark, see EncodeVITCFlags.
+func EncodeVITC(tc TimeCode, userBits UserBits) ([]byte, error) {
+	return EncodeVITCFlags(tc, userBits, LTCFlags{})
+}
+
+// EncodeVITCFlags Encodes the timecode, user bits and flags as a VITC word of 90 bits,
+// one bit per byte: the digits, flags and user bits of LTC in 9 groups of 8 bits, each
+// after a sync bit pair, then a sync bit pair and the CRC. The field mark is set in the
+// second field of the frame, in place of the biphase mark polarity correction bit of LTC.
+func EncodeVITCFlags(tc TimeCode, userBits UserBits, flags LTCFlags) ([]byte, error) {
+	layout, err := ltcRateLayout(tc.FrameRate())
+	if err != nil {
+		return nil, err
+	}
+	frame, err := EncodeLTCFlags(tc, userBits, flags)
+	if err != nil {
+		return nil, err
+	}
+	frame.setBits(layout.polarity, 1, tc.Field())
+	ret := make([]byte, _vitcBits)
+	for i := uint(0); i <= 80; i += 10 {
+		ret[i] = 1
+	}
+	for n := uint(0); n < 64; n++ {
+		ret[vitcBit(n)] = byte(frame.bits(n, 1))
+	}
+	crc := vitcCRC(ret)
+	copy(ret[82:], crc[:])
+	return ret, nil
+}
+
+// DecodeVITC Decodes a VITC word of 90 bits, one bit per byte, at the frame rate, after
+// checking its sync bit pairs and CRC. The timecode is of the second field of the frame
+// when the field mark is set. Like DecodeLTC the drop frame flag selects the drop frame
+// or non drop frame variant of the rate.
+func DecodeVITC(bits []byte, rate SmpteFrameRate) (*TimeCode, UserBits, error) {
+	layout, err := ltcRateLayout(rate)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(bits) != _vitcBits {
+		return nil, 0, fmt.Errorf("timecode: invalid number of VITC bits: '%v'", len(bits))
+	}
+	for i := 0; i <= 80; i += 10 {
+		if bits[i] != 1 || bits[i+1] != 0 {
+			return nil, 0, errors.New("timecode: VITC word has no sync bits")
+		}
+	}
+	if crc := vitcCRC(bits); string(crc[:]) != string(bits[82:]) {
+		return nil, 0, errors.New("timecode: VITC word fails the CRC")
+	}
+	var frame LTCFrame
+	for n := uint(0); n < 64; n++ {
+		frame.setBits(n, 1, int(bits[vitcBit(n)]))
+	}
+	frame.setBits(64, 16, _ltcSyncWord)
+	tc, userBits, err := DecodeLTC(frame, rate)
+	if err != nil || frame.bits(layout.polarity, 1) == 0 {
+		return tc, userBits, err
+	}
+	ret, err := tc.PlusFields(1)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &ret, userBits, nil
+}
+
+// DecodeVITCLine Decodes the VITC word of a line of luma samples, of any bit depth and
+// number of samples, e.g. a line of the vertical blanking interval captured as 8 or 10
+// bit video. The line is sliced halfway between its darkest and brightest samples, and
+// the bit period is measured from the falling edge in the middle of each sync bit pair.
+func DecodeVITCLine(line []uint16, rate SmpteFrameRate) (*TimeCode, UserBits, error) {
+	bits, err := vitcLineBits(line)
+	if err != nil {
+		return nil, 0, err
+	}
+	return DecodeVITC(bits, rate)
+}
+
+// vitcLineBits Returns the 90 bits of the VITC word of a line of luma samples.
+func vitcLineBits(line []uint16) ([]byte, error) {
+	missing := errors.New("timecode: no VITC word in the line")
+	if len(line) < 2*_vitcBits {
+		return nil, missing
+	}
+	low, high := line[0], line[0]
+	for _, sample := range line {
+		if sample < low {
+			low = sample
+		}
+		if sample > high {
+			high = sample
+		}
+	}
+	if high-low < 2 {
+		return nil, missing
+	}
+	slice := (float64(low) + float64(high)) / 2
+	// crossing Returns the position where the line passes the slicing level, rising or
+	// falling, in the samples from start to end, or -1 when it does not.
+	crossing := func(start, end float64, rising bool) float64 {
+		for i := int(math.Max(1, math.Ceil(start))); i < len(line) && float64(i) <= end; i++ {
+			before, after := float64(line[i-1]), float64(line[i])
+			if (before < slice && after >= slice && rising) || (before >= slice && after < slice && !rising) {
+				return float64(i-1) + (slice-before)/(after-before)
+			}
+		}
+		return -1
+	}
+
+	// the first bit of the word is the 1 of the first sync bit pair
+	start := crossing(0, float64(len(line)), true)
+	if start < 0 {
+		return nil, missing
+	}
+	fall := crossing(start, float64(len(line)), false)
+	if fall < 0 {
+		return nil, missing
+	}
+	period := fall - start
+	// fit the start and period to the falling edges of the sync bit pairs, at bit 10k+1
+	var sumK, sumX, sumKK, sumKX, count float64
+	for k := 0.0; k <= 8; k++ {
+		expected := start + (10*k+1)*period
+		x := crossing(expected-period/2, expected+period/2, false)
+		if x < 0 {
+			return nil, missing
+		}
+		bit := 10*k + 1
+		sumK, sumX, sumKK, sumKX, count = sumK+bit, sumX+x, sumKK+bit*bit, sumKX+bit*x, count+1
+		// refine the start and period by least squares as the edges are found
+		if count > 1 {
+			period = (count*sumKX - sumK*sumX) / (count*sumKK - sumK*sumK)
+			start = (sumX - period*sumK) / count
+		}
+	}
+	if start+_vitcBits*period > float64(len(line)) {
+		return nil, missing
+	}
+	ret := make([]byte, _vitcBits)
+	for i := range ret {
+		if float64(line[int(start+(float64(i)+0.5)*period)]) >= slice {
+			ret[i] = 1
+		}
+	}
+	return ret, nil
+}
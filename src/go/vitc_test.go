@@ -0,0 +1,125 @@
+package timecode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// vitcLine Returns a line of luma samples with the VITC word from the sample offset, at
+// the samples per bit, with black and white levels and transitions over about a sample.
+func vitcLine(bits []byte, length int, offset, period float64, black, white uint16) []uint16 {
+	level := func(x float64) float64 {
+		i := int((x - offset) / period)
+		if x < offset || i >= len(bits) || bits[i] == 0 {
+			return float64(black)
+		}
+		return float64(white)
+	}
+	ret := make([]uint16, length)
+	for i := range ret {
+		x := float64(i)
+		ret[i] = uint16((level(x-0.5) + 2*level(x) + level(x+0.5)) / 4)
+	}
+	return ret
+}
+
+func Test_EncodeVITC(t *testing.T) {
+	tc, _ := FromTimeCode("12:34:56:07", Smpte30)
+	bits, err := EncodeVITC(*tc, 0x87654321)
+	assert.Nil(t, err)
+	assert.Equal(t, 90, len(bits))
+	for i := 0; i <= 80; i += 10 {
+		assert.Equal(t, []byte{1, 0}, bits[i:i+2])
+	}
+	// frame units 7 and user bits group 1, LSB first
+	assert.Equal(t, []byte{1, 1, 1, 0, 1, 0, 0, 0}, bits[2:10])
+	// hours units 2 and user bits group 7
+	assert.Equal(t, []byte{0, 1, 0, 0, 1, 1, 1, 0}, bits[62:70])
+	// the CRC leaves every eighth bit of the word with an even number of ones
+	for lane := 0; lane < 8; lane++ {
+		ones := 0
+		for i := lane; i < 90; i += 8 {
+			ones += int(bits[i])
+		}
+		assert.Equal(t, 0, ones%2, lane)
+	}
+	// drop frame and color frame flags
+	tc, _ = FromTimeCode("00:00:00;00", Smpte2997Drop)
+	bits, _ = EncodeVITCFlags(*tc, 0, LTCFlags{ColorFrame: true})
+	assert.Equal(t, []byte{1, 1}, bits[14:16])
+	// the field mark, bit 35 at 30 fps and bit 75 at 25 fps
+	tc, _ = FromFieldTimeCode("00:00:00:00.1", Smpte30)
+	bits, _ = EncodeVITC(*tc, 0)
+	assert.Equal(t, byte(1), bits[35])
+	assert.Equal(t, byte(0), bits[75])
+	tc, _ = FromFieldTimeCode("00:00:00:00.1", Smpte25)
+	bits, _ = EncodeVITC(*tc, 0)
+	assert.Equal(t, byte(0), bits[35])
+	assert.Equal(t, byte(1), bits[75])
+	tc, _ = FromTimeCode("00:00:00:00", Smpte25)
+	bits, _ = EncodeVITCFlags(*tc, 0, LTCFlags{BinaryGroup: [3]bool{true, false, false}})
+	assert.Equal(t, byte(1), bits[35])
+	assert.Equal(t, byte(0), bits[75])
+
+	tc, _ = FromTimeCode("00:00:00:00", Smpte50)
+	_, err = EncodeVITC(*tc, 0)
+	assert.NotNil(t, err)
+}
+
+func Test_DecodeVITC(t *testing.T) {
+	for _, rate := range []SmpteFrameRate{Smpte24, Smpte25, Smpte2997Drop, Smpte2997NonDrop, Smpte30} {
+		for _, s := range []string{"00:00:00:00.0", "00:00:00:00.1", "01:00:00:02.1", "23:59:59:23.0"} {
+			tc, _ := FromFieldTimeCode(s, rate)
+			bits, err := EncodeVITC(*tc, 0xCAFE0123)
+			assert.Nil(t, err, s)
+			back, userBits, err := DecodeVITC(bits, rate)
+			assert.Nil(t, err, s)
+			assert.Equal(t, tc.StringField(), back.StringField(), s)
+			assert.Equal(t, UserBits(0xCAFE0123), userBits, s)
+		}
+	}
+
+	tc, _ := FromTimeCode("01:00:00:00", Smpte25)
+	bits, _ := EncodeVITC(*tc, 0)
+	_, _, err := DecodeVITC(bits[:89], Smpte25)
+	assert.NotNil(t, err)
+	_, _, err = DecodeVITC(bits, Smpte50)
+	assert.NotNil(t, err)
+	// a bit error fails the CRC
+	bits[40] ^= 1
+	_, _, err = DecodeVITC(bits, Smpte25)
+	assert.NotNil(t, err)
+	bits[40] ^= 1
+	// the 0 of a sync bit pair
+	bits[51] = 1
+	_, _, err = DecodeVITC(bits, Smpte25)
+	assert.NotNil(t, err)
+}
+
+func Test_DecodeVITCLine(t *testing.T) {
+	// 525 line video at 13.5 MHz, 858 samples a line, about 7.46 samples a bit
+	tc, _ := FromFieldTimeCode("10:11:12;13.1", Smpte2997Drop)
+	bits, _ := EncodeVITC(*tc, 0x12345678)
+	line := vitcLine(bits, 858, 77.3, 858.0/115, 16, 200)
+	back, userBits, err := DecodeVITCLine(line, Smpte2997Drop)
+	assert.Nil(t, err)
+	assert.Equal(t, "10:11:12;13.1", back.StringField())
+	assert.Equal(t, UserBits(0x12345678), userBits)
+
+	// 625 line video at 10 bits and 27 MHz, 1728 samples a line
+	tc, _ = FromTimeCode("23:59:59:24", Smpte25)
+	bits, _ = EncodeVITC(*tc, 0)
+	line = vitcLine(bits, 1728, 150.6, 1728.0/116, 64, 800)
+	back, _, err = DecodeVITCLine(line, Smpte25)
+	assert.Nil(t, err)
+	assert.Equal(t, "23:59:59:24", back.String())
+
+	// no VITC word
+	_, _, err = DecodeVITCLine(make([]uint16, 858), Smpte25)
+	assert.NotNil(t, err)
+	_, _, err = DecodeVITCLine(line[:100], Smpte25)
+	assert.NotNil(t, err)
+	_, _, err = DecodeVITCLine(line[:1000], Smpte25)
+	assert.NotNil(t, err)
+}